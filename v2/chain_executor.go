@@ -1,98 +1,160 @@
 package v2
 
-//
-//import (
-//	"context"
-//	"fmt"
-//	"sync"
-//)
-//
-//type ExecuteResultMap struct {
-//	results sync.Map
-//}
-//
-//func NewExecuteResultMap() *ExecuteResultMap {
-//	return &ExecuteResultMap{results: sync.Map{}}
-//}
-//
-//func (e *ExecuteResultMap) addResult(key any, result *ExecuteResult) {
-//	e.results.Store(key, result)
-//}
-//
-//func (e *ExecuteResultMap) Slice() []*ExecuteResult {
-//	rts := make([]*ExecuteResult, 0)
-//	e.results.Range(func(key, value any) bool {
-//		rts = append(rts, value.(*ExecuteResult))
-//		return true // 끝까지 돌아라!
-//	})
-//	return rts
-//}
-//
-//type ExecuteResult struct {
-//	NodeFlow string
-//	NodeID   string
-//	Req      any
-//	Res      any
-//	Err      error
-//}
-//
-//func NewExecuteResult(nodeFlow, nodeID string, req, res any, err error) *ExecuteResult {
-//	return &ExecuteResult{
-//		NodeFlow: nodeFlow,
-//		NodeID:   nodeID,
-//		Req:      req,
-//		Res:      res,
-//		Err:      err,
-//	}
-//}
-//
-//type FunctionChainExecutor struct {
-//	registry         FunctionRegistry
-//	adapter          FunctionAdapter
-//	executeResultMap *ExecuteResultMap
-//	current          int32
-//}
-//
-//func NewFunctionChainExecutor(registry FunctionRegistry, adapter FunctionAdapter) *FunctionChainExecutor {
-//	return &FunctionChainExecutor{
-//		registry:         registry,
-//		adapter:          adapter,
-//		executeResultMap: NewExecuteResultMap(),
-//		current:          0,
-//	}
-//}
-//
-//func (e *FunctionChainExecutor) Execute(startID string, ctx context.Context, req any) (*ExecuteResultMap, error) {
-//	startNode, ok := e.registry.GetFunctionNode(startID)
-//	if !ok {
-//		return e.executeResultMap, fmt.Errorf("function node not found: %s", startID)
-//	}
-//
-//	// Adapter 를 사용하여 인자 변환
-//	// TODO : StartID => Next 를 따져서 변홚 해야함
-//	newReq, err := e.adapter.Adapt(startID, ctx, req, func(ctx context.Context, after any, err error) {
-//		e.current++
-//		e.executeResultMap.addResult(e.current, NewExecuteResult(GetNodeFlowInContext(ctx), startID, req, after, err))
-//	})
-//	if err != nil {
-//		return e.executeResultMap, err
-//	}
-//
-//	res, err := startNode.Function(ctx, newReq)
-//	e.current++
-//	e.executeResultMap.addResult(e.current, NewExecuteResult(GetNodeFlowInContext(ctx), startID, newReq, res, err))
-//
-//	if err != nil {
-//		return e.executeResultMap, err
-//	}
-//
-//	for _, nextID := range startNode.Next.GetElems() {
-//		ctx = SetNodeFlowInContext(ctx, startID)
-//		_, err := e.Execute(nextID, ctx, res)
-//		if err != nil {
-//			return e.executeResultMap, err
-//		}
-//	}
-//
-//	return e.executeResultMap, nil
-//}
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type ExecuteResultMap struct {
+	results sync.Map
+}
+
+func NewExecuteResultMap() *ExecuteResultMap {
+	return &ExecuteResultMap{results: sync.Map{}}
+}
+
+func (e *ExecuteResultMap) addResult(key int32, result *ExecuteResult) {
+	e.results.Store(key, result)
+}
+
+// Slice | 실행된 순서대로 ExecuteResult 를 반환합니다.
+func (e *ExecuteResultMap) Slice() []*ExecuteResult {
+	keys := make([]int32, 0)
+	e.results.Range(func(key, value any) bool {
+		keys = append(keys, key.(int32))
+		return true // 끝까지 돌아라!
+	})
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	rts := make([]*ExecuteResult, 0, len(keys))
+	for _, key := range keys {
+		value, _ := e.results.Load(key)
+		rts = append(rts, value.(*ExecuteResult))
+	}
+	return rts
+}
+
+type ExecuteResult struct {
+	ExecutionInfo
+	NodeFlow string
+	NodeID   string
+	Req      any
+	Res      any
+	Err      error
+}
+
+func NewExecuteResult(ctx context.Context, nodeID string, req, res any, err error) *ExecuteResult {
+	info, _ := GetExecutionInfo(ctx)
+	return &ExecuteResult{
+		ExecutionInfo: info,
+		NodeFlow:      GetNodeFlowInContext(ctx),
+		NodeID:        nodeID,
+		Req:           req,
+		Res:           res,
+		Err:           err,
+	}
+}
+
+// FunctionChainExecutor | FunctionRegistry 에 연결된 노드들을 startID 부터 순서대로 실행합니다.
+// 한 번의 Execute 는 하나의 run 이며, 각 노드 호출마다 InvocationID 가 발급됩니다.
+type FunctionChainExecutor struct {
+	registry FunctionRegistry
+	adapter  FunctionAdapter
+}
+
+// NewFunctionChainExecutor | adapter 가 있으면 각 노드를 호출하기 전에 노드 id 의 adapter 로 요청을 변환합니다. (nil 이면 변환하지 않음)
+func NewFunctionChainExecutor(registry FunctionRegistry, adapter FunctionAdapter) *FunctionChainExecutor {
+	return &FunctionChainExecutor{
+		registry: registry,
+		adapter:  adapter,
+	}
+}
+
+// Execute | startID 노드부터 연결된 노드를 깊이 우선으로 실행합니다.
+// ctx 에 Replay 가 있으면 대체 지정된 노드는 실행하지 않고 기록된 output 을 사용하며,
+// ctx 에 DryRun 이 있으면 노드 대신 stub 을 호출합니다.
+// ctx 에 EventBus 가 있으면 run, node, adapter 의 lifecycle Event 를 발행합니다.
+func (e *FunctionChainExecutor) Execute(ctx context.Context, startID string, req any) (*ExecuteResultMap, error) {
+	results := NewExecuteResultMap()
+	var current int32
+
+	ctx, started := StartRun(ctx)
+	begin := time.Now()
+	err := e.execute(ctx, startID, req, results, &current)
+	if started {
+		PublishEvent(ctx, Event{Type: RunFinished, NodeID: startID, Req: req, Err: err, Duration: time.Since(begin)})
+	}
+	return results, err
+}
+
+func (e *FunctionChainExecutor) execute(ctx context.Context, nodeID string, req any, results *ExecuteResultMap, current *int32) error {
+	node, ok := e.registry.GetFunctionNode(nodeID)
+	if !ok {
+		return &FunctionNotFoundError{Name: nodeID}
+	}
+
+	// 실행 경로에 이미 있는 노드라면 순환 연결임
+	if flow, _ := GetNodeFlow(ctx); flowContains(flow, nodeID) {
+		return &CycleError{Name: nodeID, Path: flow.IDs()}
+	}
+
+	ctx = SetNodeFlowInContext(ctx, nodeID)
+	ctx, _ = WithInvocation(ctx)
+
+	if e.adapter != nil {
+		adapted, err := e.adapter.Adapt(nodeID, ctx, req, nil)
+		if err != nil {
+			results.addResult(atomic.AddInt32(current, 1), NewExecuteResult(ctx, nodeID, req, nil, err))
+			return err
+		}
+		req = adapted
+	}
+
+	PublishEvent(ctx, Event{Type: NodeStarted, NodeID: nodeID, Req: req})
+	begin := time.Now()
+	res, substituted, err := SubstitutedOutput(ctx, nodeID, node.Function.GetResponseType(), req)
+	if !substituted {
+		res, err = node.Function.Call(ctx, req)
+	}
+	PublishEvent(ctx, Event{Type: NodeFinished, NodeID: nodeID, Req: req, Res: res, Err: err, Duration: time.Since(begin)})
+	results.addResult(atomic.AddInt32(current, 1), NewExecuteResult(ctx, nodeID, req, res, err))
+	if err != nil {
+		return err
+	}
+
+	for _, nextID := range node.NextIDs() {
+		// 연결 시 등록된 adapter 로 다음 노드의 요청 타입을 맞춰줌
+		nextReq := res
+		for i, adapter := range node.Adapters[nextID] {
+			adapterReq := nextReq
+			begin := time.Now()
+			var simulated bool
+			if nextReq, simulated, err = SimulatedConverterOutput(ctx, fmt.Sprintf("%s->%s[%d]", nodeID, nextID, i), adapterReq); !simulated {
+				nextReq, err = adapter.Call(ctx, adapterReq)
+			}
+			if err != nil {
+				PublishEvent(ctx, Event{Type: ConverterFailed, NodeID: nextID, Req: adapterReq, Err: err, Duration: time.Since(begin)})
+				return err
+			}
+			PublishEvent(ctx, Event{Type: ConverterFinished, NodeID: nextID, Req: adapterReq, Res: nextReq, Duration: time.Since(begin)})
+		}
+		if err := e.execute(ctx, nextID, nextReq, results, current); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func flowContains(flow NodeFlow, nodeID string) bool {
+	for _, step := range flow.Steps {
+		if step.NodeID == nodeID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func newIntFunction(t *testing.T, fn func(int) int) AnyFunction {
+	f, err := NewDecoratedFunctionBuilder[int, int]().
+		Func(func(ctx context.Context, req int) (int, error) { return fn(req), nil }).
+		Build().Any()
+	if err != nil {
+		t.Fatalf("failed to create AnyFunction: %v", err)
+	}
+	return f
+}
+
+func TestFunctionChainExecutor(t *testing.T) {
+	registry := NewFunctionRegistry()
+	registry.RegisterFunction("inc", newIntFunction(t, func(i int) int { return i + 1 }))
+	registry.RegisterFunction("double", newIntFunction(t, func(i int) int { return i * 2 }))
+	registry.RegisterFunction("square", newIntFunction(t, func(i int) int { return i * i }))
+	toString, _ := NewAnyFunction(reflect.TypeOf(0), reflect.TypeOf(""), func(ctx context.Context, req any) (any, error) {
+		return strconv.Itoa(req.(int)), nil
+	})
+	registry.RegisterFunction("toString", toString)
+
+	// inc -> double, inc -> square -> toString
+	if err := registry.ConnectFunctionNode("inc", "double"); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.ConnectFunctionNode("inc", "square"); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.ConnectFunctionNode("square", "toString"); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := NewFunctionChainExecutor(registry, nil).Execute(context.Background(), "inc", 2)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	rs := results.Slice()
+	want := []struct {
+		flow string
+		res  any
+	}{
+		{"inc", 3},
+		{"inc/double", 6},
+		{"inc/square", 9},
+		{"inc/square/toString", "9"},
+	}
+	if len(rs) != len(want) {
+		t.Fatalf("len(results) = %d, want %d", len(rs), len(want))
+	}
+
+	invocations := make(map[string]string)
+	for i, r := range rs {
+		if r.NodeFlow != want[i].flow || r.Res != want[i].res {
+			t.Errorf("results[%d] = (%s, %v), want (%s, %v)", i, r.NodeFlow, r.Res, want[i].flow, want[i].res)
+		}
+		// 모든 노드는 하나의 run 에 속해야 함
+		if r.RunID == "" || r.RunID != rs[0].RunID {
+			t.Errorf("results[%d].RunID = %s, want %s", i, r.RunID, rs[0].RunID)
+		}
+		invocations[r.NodeID] = r.InvocationID
+	}
+	if rs[3].ParentInvocationID != invocations["square"] {
+		t.Errorf("toString parent = %s, want %s", rs[3].ParentInvocationID, invocations["square"])
+	}
+
+	// 다른 Execute 는 다른 run 이어야 함
+	other, _ := NewFunctionChainExecutor(registry, nil).Execute(context.Background(), "inc", 2)
+	if other.Slice()[0].RunID == rs[0].RunID {
+		t.Errorf("each Execute should have a unique RunID")
+	}
+}
+
+func TestFunctionChainExecutorWithAdapter(t *testing.T) {
+	registry := NewFunctionRegistry()
+	toString, _ := NewAnyFunction(reflect.TypeOf(0), reflect.TypeOf(""), func(ctx context.Context, req any) (any, error) {
+		return strconv.Itoa(req.(int)), nil
+	})
+	registry.RegisterFunction("toString", toString)
+	registry.RegisterFunction("inc", newIntFunction(t, func(i int) int { return i + 1 }))
+
+	atoi, _ := NewAnyFunction(reflect.TypeOf(""), reflect.TypeOf(0), func(ctx context.Context, req any) (any, error) {
+		return strconv.Atoi(req.(string))
+	})
+	if err := registry.ConnectFunctionNode("toString", "inc", atoi); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := NewFunctionChainExecutor(registry, nil).Execute(context.Background(), "toString", 41)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	rs := results.Slice()
+	if last := rs[len(rs)-1]; last.Res != 42 {
+		t.Errorf("last result = %v, want 42", last.Res)
+	}
+}
+
+func TestFunctionChainExecutorError(t *testing.T) {
+	registry := NewFunctionRegistry()
+	fail, _ := NewAnyFunction(reflect.TypeOf(0), reflect.TypeOf(0), func(ctx context.Context, req any) (any, error) {
+		return nil, errors.New("fail")
+	})
+	registry.RegisterFunction("fail", fail)
+
+	if _, err := NewFunctionChainExecutor(registry, nil).Execute(context.Background(), "fail", 1); err == nil {
+		t.Errorf("Execute() should return error")
+	}
+	if _, err := NewFunctionChainExecutor(registry, nil).Execute(context.Background(), "none", 1); err == nil {
+		t.Errorf("Execute() should return error when start node not exists")
+	}
+}
+
+func TestFunctionChainExecutorFunctionAdapter(t *testing.T) {
+	registry := NewFunctionRegistry()
+	registry.RegisterFunction("inc", newIntFunction(t, func(i int) int { return i + 1 }))
+	registry.RegisterFunction("double", newIntFunction(t, func(i int) int { return i * 2 }))
+	if err := registry.ConnectFunctionNode("inc", "double"); err != nil {
+		t.Fatal(err)
+	}
+
+	// double 에 들어가는 요청에 10 을 더함
+	adapter := NewFunctionAdapter()
+	adapter.RegisterAdapter("double", newIntFunction(t, func(i int) int { return i + 10 }))
+	results, err := NewFunctionChainExecutor(registry, adapter).Execute(context.Background(), "inc", 1)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	rs := results.Slice()
+	if len(rs) != 2 || rs[1].Req != 12 || rs[1].Res != 24 {
+		t.Errorf("results = %+v, want double(12) = 24", rs)
+	}
+}
@@ -0,0 +1,127 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// converterKey | ConverterRegistry 에서 converter 를 찾을 때 사용하는 (원본 타입, 대상 타입) 짝
+type converterKey struct {
+	from reflect.Type
+	to   reflect.Type
+}
+
+// ConverterRegistry | (원본 타입, 대상 타입) 으로 converter 를 등록하고,
+// 두 타입 사이를 잇는 가장 짧은 converter 연결(chain)을 찾아줍니다.
+type ConverterRegistry struct {
+	mu         sync.RWMutex
+	converters map[converterKey]AnyFunction
+	order      []converterKey // 등록 순서, 같은 길이의 chain 이 여러 개일 때 먼저 등록된 쪽을 사용
+}
+
+func NewConverterRegistry() *ConverterRegistry {
+	return &ConverterRegistry{converters: make(map[converterKey]AnyFunction)}
+}
+
+// Register | converter 의 요청, 응답 타입으로 converter 를 등록합니다.
+// 같은 타입 짝의 converter 가 이미 있으면 에러를 반환합니다.
+func (r *ConverterRegistry) Register(converter AnyFunction) error {
+	if converter == nil {
+		return fmt.Errorf("converter must not be nil")
+	}
+	key := converterKey{from: converter.GetRequestType(), to: converter.GetResponseType()}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.converters[key]; exists {
+		return fmt.Errorf("converter from %s to %s is already registered", key.from, key.to)
+	}
+	r.converters[key] = converter
+	r.order = append(r.order, key)
+	return nil
+}
+
+// RegisterConverter | 타입이 명시된 func 을 converter 로 등록합니다.
+func RegisterConverter[From any, To any](r *ConverterRegistry, fn func(ctx context.Context, from From) (To, error)) error {
+	if fn == nil {
+		return fmt.Errorf("converter must not be nil")
+	}
+	fromType, toType := GetGenericType[From](), GetGenericType[To]()
+	converter, err := NewAnyFunction(fromType, toType, func(ctx context.Context, req any) (any, error) {
+		from, ok := req.(From)
+		if !ok {
+			return nil, fmt.Errorf("converter from %s to %s got %T", fromType, toType, req)
+		}
+		return fn(ctx, from)
+	})
+	if err != nil {
+		return err
+	}
+	return r.Register(converter)
+}
+
+// Get | from 에서 to 로 바로 변환하는 converter 를 반환합니다.
+func (r *ConverterRegistry) Get(from, to reflect.Type) (AnyFunction, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	converter, ok := r.converters[converterKey{from: from, to: to}]
+	return converter, ok
+}
+
+// Resolve | from 을 to 로 변환하는 가장 짧은 converter chain 을 반환합니다.
+// from 이 이미 to 에 대입 가능하면 빈 chain 을, 연결할 수 없으면 에러를 반환합니다.
+func (r *ConverterRegistry) Resolve(from, to reflect.Type) ([]AnyFunction, error) {
+	if from == nil || to == nil {
+		return nil, fmt.Errorf("types must not be nil")
+	}
+	if from.AssignableTo(to) {
+		return []AnyFunction{}, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// 너비 우선 탐색 : prev[t] 는 t 에 도달할 때 사용한 converter 와 그 직전 타입
+	type edge struct {
+		key  converterKey
+		from reflect.Type
+	}
+	prev := map[reflect.Type]edge{}
+	visited := map[reflect.Type]bool{from: true}
+	queue := []reflect.Type{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, key := range r.order {
+			if visited[key.to] || !current.AssignableTo(key.from) {
+				continue
+			}
+			visited[key.to] = true
+			prev[key.to] = edge{key: key, from: current}
+			if !key.to.AssignableTo(to) {
+				queue = append(queue, key.to)
+				continue
+			}
+			chain := make([]AnyFunction, 0)
+			for t := key.to; t != from; t = prev[t].from {
+				chain = append([]AnyFunction{r.converters[prev[t].key]}, chain...)
+			}
+			return chain, nil
+		}
+	}
+	return nil, fmt.Errorf("no converter chain from %s to %s: %w", from, to, ErrTypeMismatch)
+}
+
+// ChainName | converter chain 을 "A -> B -> C" 형태로 표현합니다.
+func ChainName(chain []AnyFunction) string {
+	if len(chain) == 0 {
+		return ""
+	}
+	names := []string{chain[0].GetRequestType().String()}
+	for _, c := range chain {
+		names = append(names, c.GetResponseType().String())
+	}
+	return strings.Join(names, " -> ")
+}
@@ -0,0 +1,84 @@
+package v2
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type celsius float64
+type fahrenheit float64
+
+func newTestConverterRegistry(t *testing.T) *ConverterRegistry {
+	converters := NewConverterRegistry()
+	if err := RegisterConverter(converters, func(ctx context.Context, i int) (celsius, error) { return celsius(i), nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterConverter(converters, func(ctx context.Context, c celsius) (fahrenheit, error) { return fahrenheit(c*9/5 + 32), nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterConverter(converters, func(ctx context.Context, f fahrenheit) (string, error) {
+		return strconv.FormatFloat(float64(f), 'f', 1, 64), nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return converters
+}
+
+func TestConverterRegistryResolve(t *testing.T) {
+	converters := newTestConverterRegistry(t)
+
+	chain, err := converters.Resolve(reflect.TypeOf(0), reflect.TypeOf(""))
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if name := ChainName(chain); name != "int -> v2.celsius -> v2.fahrenheit -> string" {
+		t.Errorf("Resolve() = %s", name)
+	}
+
+	// 더 짧은 chain 이 생기면 그쪽을 사용
+	if err := RegisterConverter(converters, func(ctx context.Context, c celsius) (string, error) { return "short", nil }); err != nil {
+		t.Fatal(err)
+	}
+	chain, _ = converters.Resolve(reflect.TypeOf(0), reflect.TypeOf(""))
+	if len(chain) != 2 {
+		t.Errorf("Resolve() = %s, want 2 converters", ChainName(chain))
+	}
+
+	if chain, err := converters.Resolve(reflect.TypeOf(0), reflect.TypeOf(0)); err != nil || len(chain) != 0 {
+		t.Errorf("Resolve() same type = (%v, %v), want empty chain", chain, err)
+	}
+	if _, err := converters.Resolve(reflect.TypeOf(""), reflect.TypeOf(0)); err == nil || !strings.Contains(err.Error(), "no converter chain") {
+		t.Errorf("Resolve() error = %v, want no converter chain", err)
+	}
+	if err := RegisterConverter(converters, func(ctx context.Context, i int) (celsius, error) { return 0, nil }); err == nil {
+		t.Error("Register() duplicated converter should fail")
+	}
+}
+
+func TestConnectFunctionNodeWithConverters(t *testing.T) {
+	registry := NewFunctionRegistryWithConverters(newTestConverterRegistry(t))
+	registry.RegisterFunction("inc", newIntFunction(t, func(i int) int { return i + 1 }))
+	echo, _ := NewAnyFunction(reflect.TypeOf(""), reflect.TypeOf(""), func(ctx context.Context, req any) (any, error) {
+		return req, nil
+	})
+	registry.RegisterFunction("echo", echo)
+	registry.RegisterFunction("toInt", newIntFunction(t, func(i int) int { return i }))
+
+	if err := registry.ConnectFunctionNode("inc", "echo"); err != nil {
+		t.Fatalf("ConnectFunctionNode() error = %v", err)
+	}
+	if err := registry.ConnectFunctionNode("echo", "toInt"); err == nil {
+		t.Error("ConnectFunctionNode() without converter chain should fail")
+	}
+
+	results, err := NewFunctionChainExecutor(registry, nil).Execute(context.Background(), "inc", 99)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if res := results.Slice()[1].Res; res != "212.0" {
+		t.Errorf("echo result = %v, want 212.0", res)
+	}
+}
@@ -3,10 +3,10 @@ package v2
 import (
 	"context"
 	"errors"
-	"fmt"
 )
 
 type DecoratedFunction[REQ any, RES any] struct {
+	name                string // 패닉 에러, Event 에 기록할 이름 (PanicDecorator 가 지정)
 	panicHandling       bool
 	requestDecorators   []func(ctx context.Context, req REQ) (REQ, error)
 	fn                  func(ctx context.Context, req REQ) (RES, error)
@@ -19,8 +19,7 @@ func (f *DecoratedFunction[REQ, RES]) Call(ctx context.Context, req REQ) (res RE
 	if f.panicHandling {
 		defer func(e *error) {
 			if r := recover(); r != nil {
-				innerErr := errors.New(fmt.Sprintf("%s", r)) // TODO : stack trace 찍게 해야 함
-				*e = innerErr
+				*e = RecoveredPanic(ctx, f.name, req, r) // TODO : stack trace 찍게 해야 함
 			}
 		}(&err)
 	}
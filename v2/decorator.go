@@ -0,0 +1,288 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CallFunc | 요청, 응답 타입이 지워진 function 호출
+// v3 의 FunctionType, AnyFunction.Call 과 같은 모양
+type CallFunc func(ctx context.Context, req any) (any, error)
+
+// Decorator | name 의 function 호출 next 를 감싼 호출을 반환합니다.
+type Decorator func(name string, next CallFunc) CallFunc
+
+// NamedDecorator | 이름으로 교체, 제외할 수 있는 Decorator
+type NamedDecorator struct {
+	Name     string
+	Decorate Decorator
+}
+
+// 기본으로 제공하는 Decorator 의 이름
+const (
+	DecoratorPanic   = "panic"
+	DecoratorLogging = "logging"
+	DecoratorMetrics = "metrics"
+	DecoratorTimeout = "timeout"
+)
+
+// DecoratorSet | 순서가 있는 Decorator 목록, 앞의 Decorator 가 바깥쪽에서 호출을 감쌉니다.
+type DecoratorSet []NamedDecorator
+
+// NewDecoratorSet | 로깅, 메트릭, 타임아웃, 패닉 처리 순으로 감싸는 기본 DecoratorSet 을 만듭니다.
+// logger, metrics 가 nil 이거나 timeout 이 0 이면 해당 Decorator 는 빠집니다.
+func NewDecoratorSet(logger *log.Logger, metrics *Metrics, timeout time.Duration) DecoratorSet {
+	set := DecoratorSet{}
+	if logger != nil {
+		set = set.With(DecoratorLogging, LoggingDecorator(logger))
+	}
+	if metrics != nil {
+		set = set.With(DecoratorMetrics, MetricsDecorator(metrics))
+	}
+	if timeout > 0 {
+		set = set.With(DecoratorTimeout, TimeoutDecorator(timeout))
+	}
+	// 타임아웃은 다른 goroutine 에서 function 을 호출하므로 패닉 처리는 가장 안쪽에 있어야 함
+	return set.With(DecoratorPanic, PanicDecorator())
+}
+
+// With | name 의 Decorator 를 교체하거나, 없으면 가장 안쪽에 추가한 새 DecoratorSet 을 반환합니다.
+// 패닉 처리(DecoratorPanic)가 있다면 새 Decorator 는 그 바깥쪽에 추가되어, 패닉 처리가 항상 가장 안쪽에 남습니다.
+func (s DecoratorSet) With(name string, decorator Decorator) DecoratorSet {
+	set := make(DecoratorSet, 0, len(s)+1)
+	for _, d := range s {
+		if d.Name == name {
+			d.Decorate = decorator
+			return append(append(set, d), s[len(set)+1:]...)
+		}
+		set = append(set, d)
+	}
+	added := NamedDecorator{Name: name, Decorate: decorator}
+	if n := len(set); n > 0 && set[n-1].Name == DecoratorPanic && name != DecoratorPanic {
+		return append(set[:n-1:n-1], added, set[n-1])
+	}
+	return append(set, added)
+}
+
+// Without | names 의 Decorator 를 뺀 새 DecoratorSet 을 반환합니다.
+func (s DecoratorSet) Without(names ...string) DecoratorSet {
+	set := make(DecoratorSet, 0, len(s))
+	for _, d := range s {
+		excluded := false
+		for _, name := range names {
+			excluded = excluded || d.Name == name
+		}
+		if !excluded {
+			set = append(set, d)
+		}
+	}
+	return set
+}
+
+// Names | Decorator 의 이름을 바깥쪽부터 순서대로 반환합니다.
+func (s DecoratorSet) Names() []string {
+	names := make([]string, 0, len(s))
+	for _, d := range s {
+		names = append(names, d.Name)
+	}
+	return names
+}
+
+// Apply | name 의 function 호출 fn 을 Decorator 로 감싸서 반환합니다.
+func (s DecoratorSet) Apply(name string, fn CallFunc) CallFunc {
+	for i := len(s) - 1; i >= 0; i-- {
+		fn = s[i].Decorate(name, fn)
+	}
+	return fn
+}
+
+// ApplyAny | AnyFunction 을 Decorator 로 감싼 AnyFunction 을 반환합니다.
+func (s DecoratorSet) ApplyAny(name string, f AnyFunction) (AnyFunction, error) {
+	if f == nil {
+		return nil, fmt.Errorf("function '%s' is nil", name)
+	}
+	if len(s) == 0 {
+		return f, nil
+	}
+	return NewAnyFunction(f.GetRequestType(), f.GetResponseType(), s.Apply(name, f.Call))
+}
+
+// PanicDecorator | DecoratedFunction 의 패닉 처리로 패닉을 PanicError 로 바꾸고 PanicRecovered Event 를 발행합니다.
+func PanicDecorator() Decorator {
+	return func(name string, next CallFunc) CallFunc {
+		f := NewDecoratedFunctionBuilder[any, any]().Func(next).PanicHandling(true).Build()
+		f.name = name
+		return f.Call
+	}
+}
+
+// LoggingDecorator | 호출이 끝나면 소요 시간과 run, invocation ID, 에러를 logger 에 기록합니다.
+func LoggingDecorator(logger *log.Logger) Decorator {
+	return func(name string, next CallFunc) CallFunc {
+		return func(ctx context.Context, req any) (any, error) {
+			begin := time.Now()
+			res, err := next(ctx, req)
+			run := ""
+			if info, ok := GetExecutionInfo(ctx); ok {
+				run = " (" + info.String() + ")"
+			}
+			if err != nil {
+				logger.Printf("function '%s' failed in %s%s: %v", name, time.Since(begin), run, err)
+			} else {
+				logger.Printf("function '%s' finished in %s%s", name, time.Since(begin), run)
+			}
+			return res, err
+		}
+	}
+}
+
+// TimeoutDecorator | timeout 안에 끝나지 않으면 context.DeadlineExceeded 를 감싼 에러를 반환합니다.
+// function 은 timeout 이 걸린 ctx 를 받으며, ctx 를 무시하는 function 은 끝날 때까지 별도 goroutine 에서 실행됩니다.
+// timeout 전에 function 이 패닉이 나면 호출한 goroutine 에서 다시 패닉을 일으켜, 바깥쪽의 패닉 처리가 받을 수 있게 합니다.
+func TimeoutDecorator(timeout time.Duration) Decorator {
+	return func(name string, next CallFunc) CallFunc {
+		return func(ctx context.Context, req any) (any, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			type result struct {
+				res       any
+				err       error
+				recovered any
+			}
+			done := make(chan result, 1)
+			go func() {
+				defer func() {
+					// 다른 goroutine 의 패닉은 호출한 쪽에서 recover 할 수 없으므로 결과로 넘김
+					if r := recover(); r != nil {
+						done <- result{recovered: r}
+					}
+				}()
+				res, err := next(ctx, req)
+				done <- result{res: res, err: err}
+			}()
+			select {
+			case r := <-done:
+				if r.recovered != nil {
+					panic(r.recovered)
+				}
+				return r.res, r.err
+			case <-ctx.Done():
+				return nil, fmt.Errorf("function '%s' timed out after %s: %w", name, timeout, ctx.Err())
+			}
+		}
+	}
+}
+
+// FunctionMetrics | function 별 호출 통계
+type FunctionMetrics struct {
+	Calls         int64
+	Errors        int64
+	TotalDuration time.Duration
+}
+
+// Metrics | MetricsDecorator 가 기록하는 function 별 호출 통계
+type Metrics struct {
+	mu        sync.Mutex
+	functions map[string]*FunctionMetrics
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{functions: make(map[string]*FunctionMetrics)}
+}
+
+func (m *Metrics) record(name string, d time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.functions[name]
+	if !ok {
+		f = &FunctionMetrics{}
+		m.functions[name] = f
+	}
+	f.Calls++
+	f.TotalDuration += d
+	if err != nil {
+		f.Errors++
+	}
+}
+
+// Get | name 의 호출 통계를 반환합니다.
+func (m *Metrics) Get(name string) FunctionMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if f, ok := m.functions[name]; ok {
+		return *f
+	}
+	return FunctionMetrics{}
+}
+
+// Names | 호출 통계가 있는 function 의 이름을 정렬하여 반환합니다.
+func (m *Metrics) Names() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.functions))
+	for name := range m.functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MetricsDecorator | 호출 수, 에러 수, 소요 시간을 metrics 에 기록합니다.
+func MetricsDecorator(metrics *Metrics) Decorator {
+	return func(name string, next CallFunc) CallFunc {
+		return func(ctx context.Context, req any) (any, error) {
+			begin := time.Now()
+			res, err := next(ctx, req)
+			metrics.record(name, time.Since(begin), err)
+			return res, err
+		}
+	}
+}
+
+// DecoratingFunctionRegistry | 등록되는 모든 function 을 기본 DecoratorSet 으로 감싸는 FunctionRegistry
+// RegisterFunctionWithDecorators 로 function 별로 다른 DecoratorSet 을 사용할 수 있습니다.
+type DecoratingFunctionRegistry struct {
+	FunctionRegistry
+	defaults DecoratorSet
+}
+
+// NewDecoratingFunctionRegistry | registry 에 등록되는 function 을 defaults 로 감싸는 registry 를 만듭니다.
+func NewDecoratingFunctionRegistry(registry FunctionRegistry, defaults DecoratorSet) *DecoratingFunctionRegistry {
+	return &DecoratingFunctionRegistry{FunctionRegistry: registry, defaults: defaults}
+}
+
+// Defaults | 기본 DecoratorSet 을 반환합니다. With, Without 으로 function 별 DecoratorSet 을 만들 때 사용합니다.
+func (r *DecoratingFunctionRegistry) Defaults() DecoratorSet {
+	return r.defaults
+}
+
+func (r *DecoratingFunctionRegistry) RegisterFunction(id string, f AnyFunction) error {
+	return r.RegisterFunctionWithDecorators(id, f, r.defaults)
+}
+
+// RegisterFunctionWithDecorators | 기본 DecoratorSet 대신 decorators 로 감싸서 등록합니다.
+func (r *DecoratingFunctionRegistry) RegisterFunctionWithDecorators(id string, f AnyFunction, decorators DecoratorSet) error {
+	decorated, err := decorators.ApplyAny(id, f)
+	if err != nil {
+		return err
+	}
+	return r.FunctionRegistry.RegisterFunction(id, decorated)
+}
+
+func (r *DecoratingFunctionRegistry) ReplaceFunction(id string, f AnyFunction) error {
+	return r.ReplaceFunctionWithDecorators(id, f, r.defaults)
+}
+
+// ReplaceFunctionWithDecorators | 기본 DecoratorSet 대신 decorators 로 감싸서 교체합니다.
+func (r *DecoratingFunctionRegistry) ReplaceFunctionWithDecorators(id string, f AnyFunction, decorators DecoratorSet) error {
+	decorated, err := decorators.ApplyAny(id, f)
+	if err != nil {
+		return err
+	}
+	return r.FunctionRegistry.ReplaceFunction(id, decorated)
+}
@@ -0,0 +1,128 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecoratorSet(t *testing.T) {
+	var buf bytes.Buffer
+	metrics := NewMetrics()
+	set := NewDecoratorSet(log.New(&buf, "", 0), metrics, 50*time.Millisecond)
+	if got := set.Names(); !reflect.DeepEqual(got, []string{DecoratorLogging, DecoratorMetrics, DecoratorTimeout, DecoratorPanic}) {
+		t.Errorf("Names() = %v", got)
+	}
+
+	panicking := set.Apply("boom", func(ctx context.Context, req any) (any, error) { panic("oops") })
+	if _, err := panicking(context.Background(), 1); err == nil || !strings.Contains(err.Error(), "function 'boom' panicked: oops") {
+		t.Errorf("panic error = %v", err)
+	}
+
+	slow := set.Apply("slow", func(ctx context.Context, req any) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if _, err := slow(context.Background(), 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("timeout error = %v, want DeadlineExceeded", err)
+	}
+
+	ok := set.Apply("ok", func(ctx context.Context, req any) (any, error) { return req, nil })
+	ok(context.Background(), 1)
+	ok(context.Background(), 2)
+	if m := metrics.Get("ok"); m.Calls != 2 || m.Errors != 0 {
+		t.Errorf("metrics(ok) = %+v", m)
+	}
+	if m := metrics.Get("boom"); m.Calls != 1 || m.Errors != 1 {
+		t.Errorf("metrics(boom) = %+v", m)
+	}
+	if !strings.Contains(buf.String(), "function 'ok' finished") || !strings.Contains(buf.String(), "function 'slow' failed") {
+		t.Errorf("log = %s", buf.String())
+	}
+
+	// run 에 속한 호출은 로그에 run, invocation ID 가 함께 기록됨
+	buf.Reset()
+	runCtx, info := WithInvocation(EnsureRun(context.Background()))
+	ok(runCtx, 3)
+	if !strings.Contains(buf.String(), "("+info.String()+")") {
+		t.Errorf("log = %s, want %s", buf.String(), info)
+	}
+
+	if got := set.Without(DecoratorLogging, DecoratorTimeout).Names(); !reflect.DeepEqual(got, []string{DecoratorMetrics, DecoratorPanic}) {
+		t.Errorf("Without() = %v", got)
+	}
+}
+
+func TestDecoratorSetWithKeepsPanicInnermost(t *testing.T) {
+	set := NewDecoratorSet(nil, nil, 0).With(DecoratorTimeout, TimeoutDecorator(time.Second))
+	if got := set.Names(); !reflect.DeepEqual(got, []string{DecoratorTimeout, DecoratorPanic}) {
+		t.Errorf("Names() = %v, want panic innermost", got)
+	}
+	// 교체는 자리를 유지함
+	if got := set.With(DecoratorTimeout, TimeoutDecorator(time.Minute)).Names(); !reflect.DeepEqual(got, []string{DecoratorTimeout, DecoratorPanic}) {
+		t.Errorf("Names() after replace = %v", got)
+	}
+
+	listener := NewChannelListener(1)
+	bus := NewEventBus()
+	bus.Subscribe(listener)
+	panicking := set.Apply("boom", func(ctx context.Context, req any) (any, error) { panic("oops") })
+	_, err := panicking(WithEventBus(context.Background(), bus), 1)
+	var panicErr *PanicError
+	if !errors.Is(err, ErrPanic) || !errors.As(err, &panicErr) || panicErr.Name != "boom" || panicErr.Value != "oops" {
+		t.Errorf("panic error = %v, want PanicError(boom)", err)
+	}
+	if e := <-listener.Events(); e.Type != PanicRecovered || e.NodeID != "boom" {
+		t.Errorf("event = %s %s, want PanicRecovered boom", e.Type, e.NodeID)
+	}
+
+	// 패닉 처리 없이 timeout 만 있으면 호출한 goroutine 으로 패닉이 전달됨
+	timeoutOnly := set.Without(DecoratorPanic).Apply("boom", func(ctx context.Context, req any) (any, error) { panic("oops") })
+	func() {
+		defer func() {
+			if r := recover(); r != "oops" {
+				t.Errorf("recover() = %v, want oops", r)
+			}
+		}()
+		timeoutOnly(context.Background(), 1)
+	}()
+}
+
+func TestDecoratingFunctionRegistry(t *testing.T) {
+	metrics := NewMetrics()
+	registry := NewDecoratingFunctionRegistry(NewFunctionRegistry(), NewDecoratorSet(nil, metrics, 0))
+
+	panicking, _ := NewAnyFunction(reflect.TypeOf(0), reflect.TypeOf(0), func(ctx context.Context, req any) (any, error) {
+		panic("oops")
+	})
+	if err := registry.RegisterFunction("boom", panicking); err != nil {
+		t.Fatal(err)
+	}
+	// function 별로 패닉 처리를 빼고 등록
+	if err := registry.RegisterFunctionWithDecorators("raw", panicking, registry.Defaults().Without(DecoratorPanic)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewFunctionChainExecutor(registry, nil).Execute(context.Background(), "boom", 1)
+	if err == nil || !strings.Contains(err.Error(), "panicked") {
+		t.Errorf("Execute(boom) error = %v, want panic error", err)
+	}
+	if m := metrics.Get("boom"); m.Calls != 1 || m.Errors != 1 {
+		t.Errorf("metrics(boom) = %+v", m)
+	}
+
+	node, _ := registry.GetFunctionNode("raw")
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("raw function should not recover panic")
+			}
+		}()
+		node.Function.Call(context.Background(), 1)
+	}()
+}
@@ -0,0 +1,115 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// registry, 실행기에서 반환하는 에러의 종류
+// 반환되는 에러는 아래 sentinel 을 감싼 구조화된 에러이므로 errors.Is 로 종류를, errors.As 로 상세 정보를 확인할 수 있음
+var (
+	ErrFunctionNotFound  = errors.New("function not found")
+	ErrDuplicateFunction = errors.New("duplicate function")
+	ErrCycle             = errors.New("cycle")
+	ErrTypeMismatch      = errors.New("type mismatch")
+	ErrAlreadyConnected  = errors.New("already connected")
+	ErrPanic             = errors.New("panic")
+)
+
+// FunctionNotFoundError | Name 으로 등록된 function(노드)이 없음
+type FunctionNotFoundError struct {
+	Name string
+}
+
+func (e *FunctionNotFoundError) Error() string {
+	return fmt.Sprintf("function not found: %s", e.Name)
+}
+
+func (e *FunctionNotFoundError) Unwrap() error {
+	return ErrFunctionNotFound
+}
+
+// DuplicateFunctionError | Name 으로 이미 function(노드)이 등록되어 있음
+type DuplicateFunctionError struct {
+	Name string
+}
+
+func (e *DuplicateFunctionError) Error() string {
+	return fmt.Sprintf("function '%s' is already registered", e.Name)
+}
+
+func (e *DuplicateFunctionError) Unwrap() error {
+	return ErrDuplicateFunction
+}
+
+// AlreadyConnectedError | From 노드가 이미 To 노드와 연결되어 있음
+type AlreadyConnectedError struct {
+	From string
+	To   string
+}
+
+func (e *AlreadyConnectedError) Error() string {
+	return fmt.Sprintf("function node '%s' is already connected to '%s'", e.From, e.To)
+}
+
+func (e *AlreadyConnectedError) Unwrap() error {
+	return ErrAlreadyConnected
+}
+
+// CycleError | Name 의 노드가 Path 로 이어지는 흐름에 이미 있어 순환이 생김
+type CycleError struct {
+	Name string
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("function node '%s' is already in the flow (%s)", e.Name, strings.Join(e.Path, "/"))
+}
+
+func (e *CycleError) Unwrap() error {
+	return ErrCycle
+}
+
+// TypeMismatchError | From 의 응답 타입을 To 의 요청 타입으로 넘길 수 없음
+type TypeMismatchError struct {
+	From     string
+	FromType reflect.Type
+	To       string
+	ToType   reflect.Type
+}
+
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("type mismatch: '%s' returns %s but '%s' requires %s", e.From, typeName(e.FromType), e.To, typeName(e.ToType))
+}
+
+func (e *TypeMismatchError) Unwrap() error {
+	return ErrTypeMismatch
+}
+
+// PanicError | function 호출 중 패닉이 나서 복구됨
+// Name 이 없으면(DecoratedFunction 등) 패닉 값만 메시지로 사용합니다.
+type PanicError struct {
+	Name  string
+	Value any
+}
+
+func (e *PanicError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprint(e.Value)
+	}
+	return fmt.Sprintf("function '%s' panicked: %v", e.Name, e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+	return ErrPanic
+}
+
+// RecoveredPanic | recover() 로 얻은 값 r 을 PanicError 로 바꾸고 PanicRecovered Event 를 발행합니다.
+func RecoveredPanic(ctx context.Context, name string, req any, r any) error {
+	err := &PanicError{Name: name, Value: r}
+	PublishEvent(ctx, Event{Type: PanicRecovered, NodeID: name, Req: req, Err: err})
+	return err
+}
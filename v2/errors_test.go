@@ -0,0 +1,62 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRegistryErrors(t *testing.T) {
+	registry := NewFunctionRegistry()
+	registry.RegisterFunction("inc", newIntFunction(t, func(i int) int { return i + 1 }))
+	registry.RegisterFunction("double", newIntFunction(t, func(i int) int { return i * 2 }))
+	toString, _ := NewAnyFunction(reflect.TypeOf(0), reflect.TypeOf(""), func(ctx context.Context, req any) (any, error) {
+		return "", nil
+	})
+	registry.RegisterFunction("toString", toString)
+
+	err := registry.RegisterFunction("inc", toString)
+	var duplicate *DuplicateFunctionError
+	if !errors.Is(err, ErrDuplicateFunction) || !errors.As(err, &duplicate) || duplicate.Name != "inc" {
+		t.Errorf("RegisterFunction() error = %v, want DuplicateFunctionError(inc)", err)
+	}
+
+	err = registry.ConnectFunctionNode("inc", "missing")
+	var notFound *FunctionNotFoundError
+	if !errors.Is(err, ErrFunctionNotFound) || !errors.As(err, &notFound) || notFound.Name != "missing" {
+		t.Errorf("ConnectFunctionNode() error = %v, want FunctionNotFoundError(missing)", err)
+	}
+
+	err = registry.ConnectFunctionNode("toString", "inc")
+	var mismatch *TypeMismatchError
+	if !errors.Is(err, ErrTypeMismatch) || !errors.As(err, &mismatch) ||
+		mismatch.From != "toString" || mismatch.FromType != reflect.TypeOf("") || mismatch.ToType != reflect.TypeOf(0) {
+		t.Errorf("ConnectFunctionNode() error = %v, want TypeMismatchError(toString -> inc)", err)
+	}
+
+	if err := registry.ConnectFunctionNode("inc", "double"); err != nil {
+		t.Fatal(err)
+	}
+	err = registry.ConnectFunctionNode("inc", "double")
+	var connected *AlreadyConnectedError
+	if !errors.Is(err, ErrAlreadyConnected) || !errors.As(err, &connected) || connected.From != "inc" || connected.To != "double" {
+		t.Errorf("ConnectFunctionNode() error = %v, want AlreadyConnectedError(inc -> double)", err)
+	}
+	err = registry.ConnectFunctionNode("double", "inc")
+	var cycle *CycleError
+	if !errors.Is(err, ErrCycle) || !errors.As(err, &cycle) || !reflect.DeepEqual(cycle.Path, []string{"double", "inc"}) {
+		t.Errorf("ConnectFunctionNode() error = %v, want CycleError(double/inc)", err)
+	}
+
+	// 연결 이후 순환이 생긴 경우 실행기에서도 같은 에러를 반환
+	node, _ := registry.GetFunctionNode("double")
+	node.Next.Add("inc")
+	_, err = NewFunctionChainExecutor(registry, nil).Execute(context.Background(), "inc", 1)
+	if !errors.As(err, &cycle) || cycle.Name != "inc" || !reflect.DeepEqual(cycle.Path, []string{"inc", "double"}) {
+		t.Errorf("Execute() error = %v, want CycleError(inc)", err)
+	}
+	if _, err := NewFunctionChainExecutor(registry, nil).Execute(context.Background(), "missing", 1); !errors.Is(err, ErrFunctionNotFound) {
+		t.Errorf("Execute() error = %v, want ErrFunctionNotFound", err)
+	}
+}
@@ -0,0 +1,154 @@
+package v2
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventType | 실행 중 발생하는 lifecycle 이벤트의 종류
+type EventType string
+
+const (
+	RunStarted        = EventType("run_started")
+	RunFinished       = EventType("run_finished")
+	NodeStarted       = EventType("node_started")
+	NodeFinished      = EventType("node_finished")
+	ConverterFinished = EventType("converter_finished")
+	ConverterFailed   = EventType("converter_failed")
+	StageFinished     = EventType("stage_finished")
+	PanicRecovered    = EventType("panic_recovered")
+)
+
+// Event | 실행 lifecycle 이벤트
+// NodeID 는 v2 에서는 FunctionNode 의 ID, v3 에서는 step 의 이름임
+type Event struct {
+	Type EventType
+	ExecutionInfo
+	NodeID   string
+	NodeFlow string
+	Req      any
+	Res      any
+	Err      error
+	Time     time.Time
+	Duration time.Duration
+}
+
+// EventListener | Event 를 구독하는 listener
+type EventListener interface {
+	OnEvent(ctx context.Context, e Event)
+}
+
+// EventListenerFunc | func 을 EventListener 로 사용하기 위한 타입
+type EventListenerFunc func(ctx context.Context, e Event)
+
+func (f EventListenerFunc) OnEvent(ctx context.Context, e Event) {
+	f(ctx, e)
+}
+
+// EventBus | 등록된 listener 들에게 Event 를 동기적으로 전달합니다.
+type EventBus struct {
+	mu        sync.RWMutex
+	seq       int
+	listeners map[int]EventListener
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{listeners: make(map[int]EventListener)}
+}
+
+// Subscribe | listener 를 등록하고, 등록 해제 func 을 반환합니다.
+func (b *EventBus) Subscribe(l EventListener) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.seq++
+	id := b.seq
+	b.listeners[id] = l
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.listeners, id)
+	}
+}
+
+// Publish | 등록된 순서대로 listener 를 호출합니다.
+func (b *EventBus) Publish(ctx context.Context, e Event) {
+	b.mu.RLock()
+	ids := make([]int, 0, len(b.listeners))
+	for id := range b.listeners {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	listeners := make([]EventListener, 0, len(ids))
+	for _, id := range ids {
+		listeners = append(listeners, b.listeners[id])
+	}
+	b.mu.RUnlock()
+
+	for _, l := range listeners {
+		l.OnEvent(ctx, e)
+	}
+}
+
+// ChannelListener | Event 를 buffered channel 로 전달하는 listener
+// 실행을 막지 않기 위해 buffer 가 가득 차면 Event 를 버리고 Dropped 를 증가시킴
+type ChannelListener struct {
+	events  chan Event
+	dropped uint64
+}
+
+func NewChannelListener(size int) *ChannelListener {
+	return &ChannelListener{events: make(chan Event, size)}
+}
+
+func (c *ChannelListener) OnEvent(_ context.Context, e Event) {
+	select {
+	case c.events <- e:
+	default:
+		atomic.AddUint64(&c.dropped, 1)
+	}
+}
+
+// Events | Event 를 수신할 channel 을 반환합니다.
+func (c *ChannelListener) Events() <-chan Event {
+	return c.events
+}
+
+// Dropped | buffer 가 가득 차서 버려진 Event 의 수를 반환합니다.
+func (c *ChannelListener) Dropped() uint64 {
+	return atomic.LoadUint64(&c.dropped)
+}
+
+// eventBusKey | context 에 EventBus 를 저장할 때 사용하는 key
+type eventBusKey struct{}
+
+// WithEventBus | 실행 중 발생하는 Event 를 bus 로 전달하도록 설정한 context 를 반환합니다.
+func WithEventBus(ctx context.Context, bus *EventBus) context.Context {
+	return context.WithValue(ctx, eventBusKey{}, bus)
+}
+
+// GetEventBus | ctx 에 저장된 EventBus 를 반환합니다.
+func GetEventBus(ctx context.Context) (*EventBus, bool) {
+	bus, ok := ctx.Value(eventBusKey{}).(*EventBus)
+	return bus, ok && bus != nil
+}
+
+// PublishEvent | ctx 에 EventBus 가 있으면 실행 정보를 채워서 Event 를 발행합니다.
+func PublishEvent(ctx context.Context, e Event) {
+	bus, ok := GetEventBus(ctx)
+	if !ok {
+		return
+	}
+	if e.ExecutionInfo == (ExecutionInfo{}) {
+		e.ExecutionInfo, _ = GetExecutionInfo(ctx)
+	}
+	if e.NodeFlow == "" {
+		e.NodeFlow = GetNodeFlowInContext(ctx)
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	bus.Publish(ctx, e)
+}
@@ -0,0 +1,102 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEventBus(t *testing.T) {
+	bus := NewEventBus()
+	var got []EventType
+	unsubscribe := bus.Subscribe(EventListenerFunc(func(ctx context.Context, e Event) {
+		got = append(got, e.Type)
+	}))
+
+	ctx := WithEventBus(context.Background(), bus)
+	PublishEvent(ctx, Event{Type: NodeStarted})
+	unsubscribe()
+	PublishEvent(ctx, Event{Type: NodeFinished})
+
+	if !reflect.DeepEqual(got, []EventType{NodeStarted}) {
+		t.Errorf("events = %v, want [%s]", got, NodeStarted)
+	}
+}
+
+func TestChannelListener(t *testing.T) {
+	bus := NewEventBus()
+	listener := NewChannelListener(1)
+	bus.Subscribe(listener)
+
+	ctx := WithEventBus(context.Background(), bus)
+	PublishEvent(ctx, Event{Type: NodeStarted})
+	PublishEvent(ctx, Event{Type: NodeFinished}) // buffer 가 가득 차서 버려짐
+
+	if e := <-listener.Events(); e.Type != NodeStarted || e.Time.IsZero() {
+		t.Errorf("event = %+v, want %s", e, NodeStarted)
+	}
+	if listener.Dropped() != 1 {
+		t.Errorf("Dropped() = %d, want 1", listener.Dropped())
+	}
+}
+
+func TestFunctionChainExecutorEvents(t *testing.T) {
+	registry := NewFunctionRegistry()
+	registry.RegisterFunction("inc", newIntFunction(t, func(i int) int { return i + 1 }))
+	panicFn, _ := NewDecoratedFunctionBuilder[int, int]().
+		Func(func(ctx context.Context, req int) (int, error) { panic("boom") }).
+		PanicHandling(true).
+		Build().Any()
+	registry.RegisterFunction("panic", panicFn)
+	if err := registry.ConnectFunctionNode("inc", "panic"); err != nil {
+		t.Fatal(err)
+	}
+
+	bus := NewEventBus()
+	var got []EventType
+	bus.Subscribe(EventListenerFunc(func(ctx context.Context, e Event) {
+		if e.RunID == "" {
+			t.Errorf("event %s has no RunID", e.Type)
+		}
+		got = append(got, e.Type)
+	}))
+
+	ctx := WithEventBus(context.Background(), bus)
+	if _, err := NewFunctionChainExecutor(registry, nil).Execute(ctx, "inc", 1); err == nil {
+		t.Fatalf("Execute() should return recovered panic error")
+	}
+
+	want := []EventType{RunStarted, NodeStarted, NodeFinished, NodeStarted, PanicRecovered, NodeFinished, RunFinished}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestFunctionChainExecutorConverterFailedEvent(t *testing.T) {
+	registry := NewFunctionRegistry()
+	registry.RegisterFunction("a", newIntFunction(t, func(i int) int { return i }))
+	registry.RegisterFunction("b", newIntFunction(t, func(i int) int { return i }))
+	failAdapter, _ := NewAnyFunction(reflect.TypeOf(0), reflect.TypeOf(0), func(ctx context.Context, req any) (any, error) {
+		return nil, errors.New("adapter failed")
+	})
+	if err := registry.ConnectFunctionNode("a", "b", failAdapter); err != nil {
+		t.Fatal(err)
+	}
+
+	bus := NewEventBus()
+	var failed []Event
+	bus.Subscribe(EventListenerFunc(func(ctx context.Context, e Event) {
+		if e.Type == ConverterFailed {
+			failed = append(failed, e)
+		}
+	}))
+
+	ctx := WithEventBus(context.Background(), bus)
+	if _, err := NewFunctionChainExecutor(registry, nil).Execute(ctx, "a", 1); err == nil {
+		t.Fatalf("Execute() should return adapter error")
+	}
+	if len(failed) != 1 || failed[0].NodeID != "b" {
+		t.Errorf("ConverterFailed events = %+v", failed)
+	}
+}
@@ -0,0 +1,68 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+)
+
+// executionKey | context 에 ExecutionInfo 를 저장할 때 사용하는 key
+type executionKey struct{}
+
+// ExecutionInfo | 하나의 함수 호출이 어느 실행(run) 에 속하는지 나타내는 식별 정보
+// RunID 는 최상위 실행 단위로 하나 발급되며, InvocationID 는 node/step/task 호출마다 발급됨
+type ExecutionInfo struct {
+	RunID              string
+	InvocationID       string
+	ParentInvocationID string
+}
+
+// String | 로그에 바로 사용할 수 있는 형태로 반환합니다.
+func (e ExecutionInfo) String() string {
+	if e.ParentInvocationID == "" {
+		return fmt.Sprintf("run=%s invocation=%s", e.RunID, e.InvocationID)
+	}
+	return fmt.Sprintf("run=%s invocation=%s parent=%s", e.RunID, e.InvocationID, e.ParentInvocationID)
+}
+
+// EnsureRun | ctx 에 run 이 없으면 새 RunID 를 발급한 context 를 반환합니다.
+// 이미 run 에 속한 ctx 라면 그대로 반환하므로, 하위 실행은 상위 실행의 RunID 를 공유합니다.
+func EnsureRun(ctx context.Context) context.Context {
+	ctx, _ = StartRun(ctx)
+	return ctx
+}
+
+// StartRun | EnsureRun 과 같지만, 새 run 이 시작되었는지 여부를 함께 반환합니다.
+// 새 run 이 시작되면 RunStarted 이벤트를 발행합니다.
+func StartRun(ctx context.Context) (context.Context, bool) {
+	if _, ok := GetExecutionInfo(ctx); ok {
+		return ctx, false
+	}
+	ctx = context.WithValue(ctx, executionKey{}, ExecutionInfo{RunID: newExecutionID()})
+	PublishEvent(ctx, Event{Type: RunStarted})
+	return ctx, true
+}
+
+// WithInvocation | 현재 호출의 자식 InvocationID 를 발급한 context 를 반환합니다.
+// run 이 없다면 새 run 을 시작합니다.
+func WithInvocation(ctx context.Context) (context.Context, ExecutionInfo) {
+	ctx = EnsureRun(ctx)
+	parent, _ := GetExecutionInfo(ctx)
+	info := ExecutionInfo{
+		RunID:              parent.RunID,
+		InvocationID:       newExecutionID(),
+		ParentInvocationID: parent.InvocationID,
+	}
+	return context.WithValue(ctx, executionKey{}, info), info
+}
+
+// GetExecutionInfo | ctx 에 저장된 ExecutionInfo 를 반환합니다.
+func GetExecutionInfo(ctx context.Context) (ExecutionInfo, bool) {
+	info, ok := ctx.Value(executionKey{}).(ExecutionInfo)
+	return info, ok
+}
+
+// GetRunID | ctx 에 저장된 RunID 를 반환합니다.
+func GetRunID(ctx context.Context) string {
+	info, _ := GetExecutionInfo(ctx)
+	return info.RunID
+}
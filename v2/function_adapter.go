@@ -1,33 +1,34 @@
 package v2
 
-//
-//import "context"
-//
-//type FunctionAdapter interface {
-//	RegisterAdapter(id string, fn AnyFunction)
-//	Adapt(id string, ctx context.Context, before any, afterFunc func(ctx context.Context, after any, err error)) (after any, err error)
-//}
-//
-//type functionAdapter struct {
-//	adapters map[string]AnyFunction
-//}
-//
-//func NewFunctionAdapter() FunctionAdapter {
-//	return &functionAdapter{adapters: make(map[string]AnyFunction)}
-//}
-//
-//func (f *functionAdapter) RegisterAdapter(id string, fn AnyFunction) {
-//	f.adapters[id] = fn
-//}
-//
-//func (f *functionAdapter) Adapt(id string, ctx context.Context, before any, afterFunc func(ctx context.Context, after any, err error)) (after any, err error) {
-//	defer func(a *any, e *error) {
-//		if afterFunc != nil {
-//			afterFunc(ctx, *a, *e)
-//		}
-//	}(&after, &err)
-//	if adapter, ok := f.adapters[id]; ok {
-//		return adapter(ctx, before)
-//	}
-//	return before, nil
-//}
+import "context"
+
+// FunctionAdapter | 노드 id 별로 등록된 adapter 로 노드에 들어갈 요청을 변환합니다.
+type FunctionAdapter interface {
+	RegisterAdapter(id string, fn AnyFunction)
+	Adapt(id string, ctx context.Context, before any, afterFunc func(ctx context.Context, after any, err error)) (after any, err error)
+}
+
+type functionAdapter struct {
+	adapters map[string]AnyFunction
+}
+
+func NewFunctionAdapter() FunctionAdapter {
+	return &functionAdapter{adapters: make(map[string]AnyFunction)}
+}
+
+func (f *functionAdapter) RegisterAdapter(id string, fn AnyFunction) {
+	f.adapters[id] = fn
+}
+
+// Adapt | id 에 등록된 adapter 가 없으면 before 를 그대로 반환합니다.
+func (f *functionAdapter) Adapt(id string, ctx context.Context, before any, afterFunc func(ctx context.Context, after any, err error)) (after any, err error) {
+	defer func(a *any, e *error) {
+		if afterFunc != nil {
+			afterFunc(ctx, *a, *e)
+		}
+	}(&after, &err)
+	if adapter, ok := f.adapters[id]; ok {
+		return adapter.Call(ctx, before)
+	}
+	return before, nil
+}
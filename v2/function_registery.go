@@ -1,15 +1,17 @@
 package v2
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"sync"
 )
 
 type FunctionNode struct {
 	ID       string
 	Function AnyFunction
 	Next     set[string]
+	Adapters map[string][]AnyFunction // key : 다음 노드의 ID
 }
 
 func NewFunctionNode(id string, f AnyFunction) *FunctionNode {
@@ -17,18 +19,52 @@ func NewFunctionNode(id string, f AnyFunction) *FunctionNode {
 		ID:       id,
 		Function: f,
 		Next:     newSet[string](),
+		Adapters: make(map[string][]AnyFunction),
 	}
 }
 
+// withNext | toID 로의 연결을 추가한 복사본을 반환합니다. 조회 중인 노드를 바꾸지 않도록 원본은 그대로 둡니다.
+func (n *FunctionNode) withNext(toID string, adapters []AnyFunction) *FunctionNode {
+	connected := &FunctionNode{ID: n.ID, Function: n.Function, Next: n.Next.clone(), Adapters: make(map[string][]AnyFunction, len(n.Adapters)+1)}
+	for id, a := range n.Adapters {
+		connected.Adapters[id] = a
+	}
+	connected.Next.Add(toID)
+	if len(adapters) > 0 {
+		connected.Adapters[toID] = adapters
+	}
+	return connected
+}
+
+// NextIDs | 연결된 다음 노드의 ID 를 정렬하여 반환합니다.
+func (n *FunctionNode) NextIDs() []string {
+	ids := n.Next.GetElems()
+	sort.Strings(ids)
+	return ids
+}
+
+// FunctionRegistry | id 로 FunctionNode 를 등록하고 연결하는 registry
+// id 에 "add@1.2.0" 처럼 버전을 붙여 등록할 수 있으며, 조회 시 "add" 는 버전 없이 등록된 노드가 없다면 가장 높은 버전을,
+// "add@^1.0.0" 처럼 제약을 주면 제약을 만족하는 가장 높은 버전을 찾습니다.
 type FunctionRegistry interface {
-	RegisterFunction(id string, f AnyFunction)
+	// RegisterFunction | 같은 id 가 이미 등록되어 있으면 에러를 반환합니다.
+	RegisterFunction(id string, f AnyFunction) error
+	// ReplaceFunction | id 의 function 을 교체합니다. 이미 연결된 노드와 adapter 는 유지됩니다.
+	// id 가 등록되어 있지 않으면 FunctionNotFoundError 를, 기존 연결과 타입이 맞지 않으면 TypeMismatchError 를 반환합니다.
+	ReplaceFunction(id string, f AnyFunction) error
 	GetFunctionNode(id string) (*FunctionNode, bool)
+	// FunctionIDs | 등록된 노드의 id 를 정렬하여 반환합니다.
+	FunctionIDs() []string
 	DeregisterFunction(id string)
 	ConnectFunctionNode(fromId, toId string, adapters ...AnyFunction) error
 }
 
+// functionRegistry | 등록, 교체, 연결은 노드를 직접 바꾸지 않고 새 노드로 갈아 끼우므로,
+// GetFunctionNode 로 얻은 노드는 다른 goroutine 이 registry 를 바꾸는 중에도 안전하게 읽을 수 있습니다.
 type functionRegistry struct {
-	nodes map[string]*FunctionNode
+	mu         sync.RWMutex
+	nodes      map[string]*FunctionNode
+	converters *ConverterRegistry
 }
 
 func NewFunctionRegistry() FunctionRegistry {
@@ -37,27 +73,126 @@ func NewFunctionRegistry() FunctionRegistry {
 	}
 }
 
-func (r *functionRegistry) RegisterFunction(id string, f AnyFunction) {
+// NewFunctionRegistryWithConverters | ConnectFunctionNode 에 adapter 가 주어지지 않고 두 노드의 타입이 다를 때,
+// converters 에서 가장 짧은 converter chain 을 찾아 adapter 로 사용하는 FunctionRegistry 를 만듭니다.
+func NewFunctionRegistryWithConverters(converters *ConverterRegistry) FunctionRegistry {
+	return &functionRegistry{
+		nodes:      make(map[string]*FunctionNode),
+		converters: converters,
+	}
+}
+
+func (r *functionRegistry) RegisterFunction(id string, f AnyFunction) error {
+	id, err := NormalizeVersionedName(id)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.nodes[id]; exists {
+		return &DuplicateFunctionError{Name: id}
+	}
 	r.nodes[id] = NewFunctionNode(id, f)
+	return nil
+}
+
+func (r *functionRegistry) ReplaceFunction(id string, f AnyFunction) error {
+	id, err := NormalizeVersionedName(id)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	node, exists := r.nodes[id]
+	if !exists {
+		return &FunctionNotFoundError{Name: id}
+	}
+
+	// 교체된 노드로 기존 연결의 타입을 다시 검사
+	replaced := &FunctionNode{ID: id, Function: f, Next: node.Next, Adapters: node.Adapters}
+	for _, nextID := range node.NextIDs() {
+		if next, ok := r.nodes[nextID]; ok {
+			if err := checkConnectionTypes(replaced, next, node.Adapters[nextID]...); err != nil {
+				return fmt.Errorf("cannot replace '%s': %w", id, err)
+			}
+		}
+	}
+	for _, prev := range r.nodes {
+		if prev.ID != id && prev.Next.Exists(id) {
+			if err := checkConnectionTypes(prev, replaced, prev.Adapters[id]...); err != nil {
+				return fmt.Errorf("cannot replace '%s': %w", id, err)
+			}
+		}
+	}
+	r.nodes[id] = replaced
+	return nil
 }
 
 func (r *functionRegistry) GetFunctionNode(id string) (*FunctionNode, bool) {
-	node, ok := r.nodes[id]
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.getFunctionNode(id)
+}
+
+// getFunctionNode | r.mu 를 잡은 상태에서 호출해야 합니다.
+func (r *functionRegistry) getFunctionNode(id string) (*FunctionNode, bool) {
+	if node, ok := r.nodes[id]; ok {
+		return node, ok
+	}
+	name, constraint := SplitVersionedName(id)
+	versions := make([]string, 0)
+	for nodeID := range r.nodes {
+		if nodeName, version := SplitVersionedName(nodeID); nodeName == name && version != "" {
+			versions = append(versions, version)
+		}
+	}
+	version, err := ResolveVersion(versions, constraint)
+	if err != nil {
+		return nil, false
+	}
+	node, ok := r.nodes[VersionedName(name, version)]
 	return node, ok
 }
 
+func (r *functionRegistry) FunctionIDs() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.nodes))
+	for id := range r.nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 func (r *functionRegistry) DeregisterFunction(id string) {
+	if normalized, err := NormalizeVersionedName(id); err == nil {
+		id = normalized
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	delete(r.nodes, id)
 }
 
 func (r *functionRegistry) ConnectFunctionNode(fromId, toId string, adapters ...AnyFunction) error {
-	fromNode, ok := r.nodes[fromId]
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fromNode, ok := r.getFunctionNode(fromId)
 	if !ok {
-		return errors.New(fmt.Sprintf("'fromNode.id=%s' not exists", fromId))
+		return &FunctionNotFoundError{Name: fromId}
 	}
-	toNode, ok := r.nodes[toId]
+	toNode, ok := r.getFunctionNode(toId)
 	if !ok {
-		return errors.New(fmt.Sprintf("'toNode.id=%s' not exists", toId))
+		return &FunctionNotFoundError{Name: toId}
+	}
+
+	// adapter 가 없다면 ConverterRegistry 에서 타입을 맞춰줄 converter chain 을 찾음
+	if len(adapters) == 0 && r.converters != nil {
+		chain, err := r.converters.Resolve(fromNode.Function.GetResponseType(), toNode.Function.GetRequestType())
+		if err != nil {
+			return fmt.Errorf("cannot connect '%s' to '%s': %w", fromId, toId, err)
+		}
+		adapters = chain
 	}
 
 	// Node 연결 규칙 검사
@@ -65,8 +200,7 @@ func (r *functionRegistry) ConnectFunctionNode(fromId, toId string, adapters ...
 		return err
 	}
 
-	// TODO : adapters 를 넣어줘야 함
-	fromNode.Next.Add(toId) // Node 에 직접 다음 것을 넣어줌
+	r.nodes[fromNode.ID] = fromNode.withNext(toNode.ID, adapters)
 	return nil
 }
 
@@ -75,49 +209,62 @@ func (r *functionRegistry) validateConnectionFunction(fromNode, toNode *Function
 
 	// 두 노드가 같은 노드 일 때
 	if fromNode.ID == toNode.ID {
-		return fmt.Errorf("fromNode and toNode have the same ID (%s)", fromNode.ID)
+		return &CycleError{Name: fromNode.ID, Path: []string{fromNode.ID}}
 	}
 	// 이미 연결된 관계
 	if fromNode.Next.Exists(toNode.ID) {
-		return fmt.Errorf("A connection to toNode is already defined in fromNode")
+		return &AlreadyConnectedError{From: fromNode.ID, To: toNode.ID}
 	}
 	// toNode 가 fromNode 를 가리키는 상황
 	if toNode.Next.Exists(fromNode.ID) {
-		return fmt.Errorf("the connection between fromNode and toNode is recursive. ")
+		return &CycleError{Name: fromNode.ID, Path: []string{fromNode.ID, toNode.ID}}
 	}
 	// funcNode 응답 타입과 toNode 요청 타입이 다를 때 => adapters 가 타입을 맞춰줘야 함
 	if !fromNode.Function.GetResponseType().AssignableTo(toNode.Function.GetRequestType()) {
 
 		// adapters 가 비었을 때
 		if len(adapters) == 0 {
-			return fmt.Errorf("when the response type of fromNode and the request type of toNode are different, adapters can't nil")
+			return fmt.Errorf("%w, adapters can't nil", &TypeMismatchError{
+				From: fromNode.ID, FromType: fromNode.Function.GetResponseType(),
+				To: toNode.ID, ToType: toNode.Function.GetRequestType(),
+			})
 		}
 
-		// fromNode 응답 타입과 adapter[0] 의 요청 타입이 일치하는가?
-		checkList := make([]reflect.Type, 0)
+		return checkConnectionTypes(fromNode, toNode, adapters...)
+	}
 
-		// fromNode 의 응답타입 추가
-		checkList = append(checkList, fromNode.Function.GetResponseType())
-		// adapter 의 요청타입 및 응답타입 추가
-		for _, adapter := range adapters {
-			checkList = append(checkList, adapter.GetRequestType(), adapter.GetResponseType())
-		}
-		// toNode 의 요청 타입 추가
-		checkList = append(checkList, toNode.Function.GetRequestType())
-
-		// 응답, 요청 타입을 짝으로 전부 체크
-		for i := 0; i < len(checkList); i += 2 {
-			if !checkList[i].AssignableTo(checkList[i+1]) {
-				// 하나라도 타입이 맞지 않으면 에러!
-				return fmt.Errorf("checkList[%d] (%s) not equal checkList[%d] (%s)",
-					i,
-					checkList[i],
-					i+1,
-					checkList[i+1],
-				)
-			}
-		}
+	return nil
+}
+
+// checkConnectionTypes | fromNode 의 응답부터 adapters 를 거쳐 toNode 의 요청까지 타입이 이어지는지 검사합니다.
+// adapters 가 없으면 fromNode 의 응답 타입이 toNode 의 요청 타입에 바로 할당될 수 있어야 합니다.
+func checkConnectionTypes(fromNode, toNode *FunctionNode, adapters ...AnyFunction) error {
+	// fromNode 응답 타입과 adapter[0] 의 요청 타입이 일치하는가?
+	checkList := make([]reflect.Type, 0)
+
+	// fromNode 의 응답타입 추가
+	checkList = append(checkList, fromNode.Function.GetResponseType())
+	// adapter 의 요청타입 및 응답타입 추가
+	for _, adapter := range adapters {
+		checkList = append(checkList, adapter.GetRequestType(), adapter.GetResponseType())
+	}
+	// toNode 의 요청 타입 추가
+	checkList = append(checkList, toNode.Function.GetRequestType())
+
+	// checkList 와 같은 순서의 이름 (adapter 는 "from->to[i]")
+	nameList := []string{fromNode.ID}
+	for i := range adapters {
+		name := fmt.Sprintf("%s->%s[%d]", fromNode.ID, toNode.ID, i)
+		nameList = append(nameList, name, name)
 	}
+	nameList = append(nameList, toNode.ID)
 
+	// 응답, 요청 타입을 짝으로 전부 체크
+	for i := 0; i < len(checkList); i += 2 {
+		if !checkList[i].AssignableTo(checkList[i+1]) {
+			// 하나라도 타입이 맞지 않으면 에러!
+			return &TypeMismatchError{From: nameList[i], FromType: checkList[i], To: nameList[i+1], ToType: checkList[i+1]}
+		}
+	}
 	return nil
 }
@@ -2,6 +2,7 @@ package v2
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -90,3 +91,97 @@ func TestConnectFunctionNode(t *testing.T) {
 		t.Errorf("Failed to connect func1 to func3 using an adapter: %v", err)
 	}
 }
+
+func TestRegisterFunctionVersions(t *testing.T) {
+	registry := NewFunctionRegistry()
+	inc := newIntFunction(t, func(i int) int { return i + 1 })
+	double := newIntFunction(t, func(i int) int { return i * 2 })
+
+	if err := registry.RegisterFunction("inc", inc); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.RegisterFunction("inc", double); err == nil {
+		t.Error("RegisterFunction() with duplicated id should fail")
+	}
+	if err := registry.ReplaceFunction("inc", double); err != nil {
+		t.Errorf("ReplaceFunction() error = %v", err)
+	}
+	if node, _ := registry.GetFunctionNode("inc"); node.Function != double {
+		t.Error("ReplaceFunction() should replace the function")
+	}
+	if err := registry.ReplaceFunction("missing", double); !errors.Is(err, ErrFunctionNotFound) {
+		t.Errorf("ReplaceFunction() of missing id error = %v, want ErrFunctionNotFound", err)
+	}
+
+	for _, id := range []string{"calc@1.0.0", "calc@1.2.0", "calc@v2"} {
+		if err := registry.RegisterFunction(id, inc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := registry.RegisterFunction("calc@2.0.0", inc); err == nil {
+		t.Error("RegisterFunction() with duplicated version should fail")
+	}
+	tests := map[string]string{
+		"calc":        "calc@2.0.0",
+		"calc@1.2":    "calc@1.2.0",
+		"calc@^1.0.0": "calc@1.2.0",
+	}
+	for id, want := range tests {
+		if node, ok := registry.GetFunctionNode(id); !ok || node.ID != want {
+			t.Errorf("GetFunctionNode(%q) = %v, want %s", id, node, want)
+		}
+	}
+	if _, ok := registry.GetFunctionNode("calc@^3.0.0"); ok {
+		t.Error("GetFunctionNode() should fail when no version matches")
+	}
+
+	if err := registry.ConnectFunctionNode("inc", "calc@^1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if node, _ := registry.GetFunctionNode("inc"); !node.Next.Exists("calc@1.2.0") {
+		t.Errorf("ConnectFunctionNode() should connect the resolved version, got %v", node.NextIDs())
+	}
+}
+
+func TestReplaceFunctionChecksConnections(t *testing.T) {
+	registry := NewFunctionRegistry()
+	itoa, _ := NewAnyFunction(reflect.TypeOf(0), reflect.TypeOf(""), func(ctx context.Context, req any) (any, error) {
+		return strconv.Itoa(req.(int)), nil
+	})
+	length, _ := NewAnyFunction(reflect.TypeOf(""), reflect.TypeOf(0), func(ctx context.Context, req any) (any, error) {
+		return len(req.(string)), nil
+	})
+	registry.RegisterFunction("prev", newIntFunction(t, func(i int) int { return i + 1 }))
+	registry.RegisterFunction("itoa", itoa)
+	registry.RegisterFunction("length", length)
+	if err := registry.ConnectFunctionNode("prev", "itoa"); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.ConnectFunctionNode("itoa", "length"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		f    AnyFunction
+		ok   bool
+	}{
+		{"same types", itoa, true},
+		{"incoming edge mismatch", length, false}, // prev(int) -> string 요청
+		{"outgoing edge mismatch", newIntFunction(t, func(i int) int { return i }), false}, // int 응답 -> length(string)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.ReplaceFunction("itoa", tt.f)
+			if tt.ok && err != nil {
+				t.Errorf("ReplaceFunction() error = %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrTypeMismatch) {
+				t.Errorf("ReplaceFunction() error = %v, want ErrTypeMismatch", err)
+			}
+		})
+	}
+	if node, _ := registry.GetFunctionNode("itoa"); node.Function != itoa {
+		t.Error("rejected ReplaceFunction() should keep the function")
+	}
+}
@@ -0,0 +1,45 @@
+package v2
+
+// GraphNode | FunctionRegistry 에 등록된 노드의 id 와 요청, 응답 타입
+type GraphNode struct {
+	ID         string `json:"id"`
+	InputType  string `json:"inputType"`
+	OutputType string `json:"outputType"`
+}
+
+// GraphEdge | 노드 사이의 연결, Adapters 는 연결에 사용되는 adapter 의 "요청 -> 응답" 타입
+type GraphEdge struct {
+	From     string   `json:"from"`
+	To       string   `json:"to"`
+	Adapters []string `json:"adapters,omitempty"`
+}
+
+// Graph | FunctionRegistry 의 노드와 연결
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// DescribeGraph | registry 의 노드와 연결을 id 순으로 반환합니다.
+func DescribeGraph(registry FunctionRegistry) Graph {
+	graph := Graph{Nodes: make([]GraphNode, 0), Edges: make([]GraphEdge, 0)}
+	for _, id := range registry.FunctionIDs() {
+		node, ok := registry.GetFunctionNode(id)
+		if !ok {
+			continue
+		}
+		graph.Nodes = append(graph.Nodes, GraphNode{
+			ID:         node.ID,
+			InputType:  node.Function.GetRequestType().String(),
+			OutputType: node.Function.GetResponseType().String(),
+		})
+		for _, next := range node.NextIDs() {
+			edge := GraphEdge{From: node.ID, To: next}
+			for _, adapter := range node.Adapters[next] {
+				edge.Adapters = append(edge.Adapters, adapter.GetRequestType().String()+" -> "+adapter.GetResponseType().String())
+			}
+			graph.Edges = append(graph.Edges, edge)
+		}
+	}
+	return graph
+}
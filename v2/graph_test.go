@@ -0,0 +1,45 @@
+package v2
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestDescribeGraph(t *testing.T) {
+	registry := NewFunctionRegistry()
+	itoa, _ := NewAnyFunction(reflect.TypeOf(0), reflect.TypeOf(""), func(ctx context.Context, req any) (any, error) {
+		return strconv.Itoa(req.(int)), nil
+	})
+	double, _ := NewAnyFunction(reflect.TypeOf(0), reflect.TypeOf(0), func(ctx context.Context, req any) (any, error) {
+		return req.(int) * 2, nil
+	})
+	atoi, _ := NewAnyFunction(reflect.TypeOf(""), reflect.TypeOf(0), func(ctx context.Context, req any) (any, error) {
+		return strconv.Atoi(req.(string))
+	})
+	registry.RegisterFunction("double", double)
+	registry.RegisterFunction("itoa", itoa)
+	registry.RegisterFunction("next", double)
+	if err := registry.ConnectFunctionNode("double", "itoa"); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.ConnectFunctionNode("itoa", "next", atoi); err != nil {
+		t.Fatal(err)
+	}
+
+	want := Graph{
+		Nodes: []GraphNode{
+			{ID: "double", InputType: "int", OutputType: "int"},
+			{ID: "itoa", InputType: "int", OutputType: "string"},
+			{ID: "next", InputType: "int", OutputType: "int"},
+		},
+		Edges: []GraphEdge{
+			{From: "double", To: "itoa"},
+			{From: "itoa", To: "next", Adapters: []string{"string -> int"}},
+		},
+	}
+	if got := DescribeGraph(registry); !reflect.DeepEqual(got, want) {
+		t.Errorf("DescribeGraph() = %+v, want %+v", got, want)
+	}
+}
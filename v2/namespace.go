@@ -0,0 +1,256 @@
+package v2
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// AccessOperation | namespace 의 function 에 대한 작업 종류
+type AccessOperation string
+
+const (
+	AccessRegister = AccessOperation("register") // 등록, 교체, 삭제
+	AccessLookup   = AccessOperation("lookup")
+	AccessExport   = AccessOperation("export")
+)
+
+// AccessHook | namespace 의 function 에 접근할 때 호출되며, 에러를 반환하면 접근이 거부됨
+// name 은 "billing.add" 처럼 namespace 가 포함된 이름
+type AccessHook func(op AccessOperation, namespace, name string) error
+
+// AccessControl | namespace 별 AccessHook 목록
+// 상위 namespace 의 hook 은 하위 namespace 에도 적용됩니다. ("" 는 모든 namespace)
+type AccessControl struct {
+	mu    sync.RWMutex
+	hooks map[string][]AccessHook
+}
+
+func NewAccessControl() *AccessControl {
+	return &AccessControl{hooks: make(map[string][]AccessHook)}
+}
+
+// AddHook | namespace 에 hook 을 추가합니다.
+func (a *AccessControl) AddHook(namespace string, hook AccessHook) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.hooks[namespace] = append(a.hooks[namespace], hook)
+}
+
+// Check | name 이 속한 namespace 와 그 상위 namespace 의 hook 을 상위부터 차례로 호출합니다.
+func (a *AccessControl) Check(op AccessOperation, name string) error {
+	namespace, _ := SplitNamespace(name)
+	a.mu.RLock()
+	hooks := make([]AccessHook, 0)
+	for _, ns := range namespaceChain(namespace) {
+		hooks = append(hooks, a.hooks[ns]...)
+	}
+	a.mu.RUnlock()
+
+	for _, hook := range hooks {
+		if err := hook(op, namespace, name); err != nil {
+			return fmt.Errorf("%s '%s' denied: %w", op, name, err)
+		}
+	}
+	return nil
+}
+
+// namespaceChain | "a.b" 를 ["", "a", "a.b"] 처럼 상위부터 순서대로 반환합니다.
+func namespaceChain(namespace string) []string {
+	chain := []string{""}
+	if namespace == "" {
+		return chain
+	}
+	parts := strings.Split(namespace, ".")
+	for i := range parts {
+		chain = append(chain, strings.Join(parts[:i+1], "."))
+	}
+	return chain
+}
+
+// QualifiedName | namespace 와 name 을 "billing.add" 형태로 합칩니다.
+func QualifiedName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// SplitNamespace | "billing.invoice.add@1.0.0" 을 ("billing.invoice", "add@1.0.0") 으로 나눕니다.
+func SplitNamespace(name string) (namespace, local string) {
+	base, version := SplitVersionedName(name)
+	i := strings.LastIndex(base, ".")
+	if i < 0 {
+		return "", name
+	}
+	return base[:i], VersionedName(base[i+1:], version)
+}
+
+// ParentNamespace | "a.b" 의 상위 namespace "a" 를 반환합니다. 최상위는 "" 입니다.
+func ParentNamespace(namespace string) string {
+	if i := strings.LastIndex(namespace, "."); i >= 0 {
+		return namespace[:i]
+	}
+	return ""
+}
+
+// NamespacedFunctionRegistry | 하나의 FunctionRegistry 를 namespace 로 나누어 사용하는 view
+// "add" 는 이 namespace 의 "billing.add" 로 등록되며, 조회 시 이 namespace 에 없으면 상위 namespace 에서 찾습니다.
+// "invoice.add" 처럼 하위 namespace 를 포함한 이름으로 하위 namespace 의 function 에 접근할 수 있습니다.
+type NamespacedFunctionRegistry struct {
+	root      FunctionRegistry
+	namespace string
+	access    *AccessControl
+}
+
+// NewNamespacedFunctionRegistry | root 를 최상위 namespace("") 로 사용하는 registry 를 만듭니다.
+func NewNamespacedFunctionRegistry(root FunctionRegistry) *NamespacedFunctionRegistry {
+	return &NamespacedFunctionRegistry{root: root, access: NewAccessControl()}
+}
+
+// Namespace | 하위 namespace 의 registry 를 반환합니다. name 은 "invoice" 또는 "invoice.tax" 처럼 지정합니다.
+func (r *NamespacedFunctionRegistry) Namespace(name string) *NamespacedFunctionRegistry {
+	return &NamespacedFunctionRegistry{root: r.root, namespace: QualifiedName(r.namespace, name), access: r.access}
+}
+
+// Name | namespace 의 이름을 반환합니다. 최상위는 "" 입니다.
+func (r *NamespacedFunctionRegistry) Name() string {
+	return r.namespace
+}
+
+// AddAccessHook | 이 namespace (와 하위 namespace) 의 function 에 접근할 때 호출할 hook 을 추가합니다.
+func (r *NamespacedFunctionRegistry) AddAccessHook(hook AccessHook) {
+	r.access.AddHook(r.namespace, hook)
+}
+
+func (r *NamespacedFunctionRegistry) RegisterFunction(id string, f AnyFunction) error {
+	full := QualifiedName(r.namespace, id)
+	if err := r.access.Check(AccessRegister, full); err != nil {
+		return err
+	}
+	return r.root.RegisterFunction(full, f)
+}
+
+func (r *NamespacedFunctionRegistry) ReplaceFunction(id string, f AnyFunction) error {
+	full := QualifiedName(r.namespace, id)
+	if err := r.access.Check(AccessRegister, full); err != nil {
+		return err
+	}
+	return r.root.ReplaceFunction(full, f)
+}
+
+// GetFunctionNode | 이 namespace 부터 상위 namespace 순으로 id 를 찾습니다. 접근이 거부되면 찾지 못한 것으로 봅니다.
+func (r *NamespacedFunctionRegistry) GetFunctionNode(id string) (*FunctionNode, bool) {
+	full, ok := r.resolve(id)
+	if !ok || r.access.Check(AccessLookup, full) != nil {
+		return nil, false
+	}
+	return r.root.GetFunctionNode(full)
+}
+
+func (r *NamespacedFunctionRegistry) DeregisterFunction(id string) {
+	full := QualifiedName(r.namespace, id)
+	if r.access.Check(AccessRegister, full) != nil {
+		return
+	}
+	r.root.DeregisterFunction(full)
+}
+
+func (r *NamespacedFunctionRegistry) ConnectFunctionNode(fromId, toId string, adapters ...AnyFunction) error {
+	from, ok := r.resolve(fromId)
+	if !ok {
+		return &FunctionNotFoundError{Name: QualifiedName(r.namespace, fromId)}
+	}
+	to, ok := r.resolve(toId)
+	if !ok {
+		return &FunctionNotFoundError{Name: QualifiedName(r.namespace, toId)}
+	}
+	for _, full := range []string{from, to} {
+		if err := r.access.Check(AccessLookup, full); err != nil {
+			return err
+		}
+	}
+	return r.root.ConnectFunctionNode(from, to, adapters...)
+}
+
+// FunctionIDs | 이 namespace 와 하위 namespace 에 등록된 id 를 namespace 를 뺀 이름으로 반환합니다.
+func (r *NamespacedFunctionRegistry) FunctionIDs() []string {
+	ids := make([]string, 0)
+	for _, id := range r.root.FunctionIDs() {
+		if rel, ok := r.relative(id); ok {
+			ids = append(ids, rel)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ExportTo | 이 namespace 의 function 과 namespace 안에서의 연결을 dst 의 namespace 로 복사합니다.
+// 복사된 노드의 id 는 QualifiedName(namespace, 이 namespace 기준의 id) 입니다.
+// 복사하기 전에 모든 function 의 export 권한을 확인하며, 복사 중 실패하면 dst 에 복사한 function 을 모두 삭제합니다.
+func (r *NamespacedFunctionRegistry) ExportTo(dst FunctionRegistry, namespace string) (err error) {
+	ids := r.FunctionIDs()
+	nodes := make(map[string]*FunctionNode, len(ids))
+	for _, id := range ids {
+		full := QualifiedName(r.namespace, id)
+		if err := r.access.Check(AccessExport, full); err != nil {
+			return err
+		}
+		node, ok := r.root.GetFunctionNode(full)
+		if !ok {
+			return &FunctionNotFoundError{Name: full}
+		}
+		nodes[id] = node
+	}
+
+	exported := make([]string, 0, len(ids))
+	defer func() {
+		if err != nil {
+			for _, id := range exported {
+				dst.DeregisterFunction(id)
+			}
+		}
+	}()
+	for _, id := range ids {
+		if err := dst.RegisterFunction(QualifiedName(namespace, id), nodes[id].Function); err != nil {
+			return err
+		}
+		exported = append(exported, QualifiedName(namespace, id))
+	}
+	for _, id := range ids {
+		for _, nextID := range nodes[id].NextIDs() {
+			next, ok := r.relative(nextID)
+			if !ok {
+				continue // namespace 밖으로의 연결은 복사하지 않음
+			}
+			if err := dst.ConnectFunctionNode(QualifiedName(namespace, id), QualifiedName(namespace, next), nodes[id].Adapters[nextID]...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolve | 이 namespace 부터 상위 namespace 순으로 등록된 id 를 찾아 전체 id 를 반환합니다.
+func (r *NamespacedFunctionRegistry) resolve(id string) (string, bool) {
+	for ns := r.namespace; ; ns = ParentNamespace(ns) {
+		if node, ok := r.root.GetFunctionNode(QualifiedName(ns, id)); ok {
+			return node.ID, true
+		}
+		if ns == "" {
+			return "", false
+		}
+	}
+}
+
+// relative | 전체 id 가 이 namespace 에 속한다면 namespace 를 뺀 id 를 반환합니다.
+func (r *NamespacedFunctionRegistry) relative(id string) (string, bool) {
+	if r.namespace == "" {
+		return id, true
+	}
+	if !strings.HasPrefix(id, r.namespace+".") {
+		return "", false
+	}
+	return strings.TrimPrefix(id, r.namespace+"."), true
+}
@@ -0,0 +1,119 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNamespacedFunctionRegistry(t *testing.T) {
+	root := NewNamespacedFunctionRegistry(NewFunctionRegistry())
+	billing := root.Namespace("billing")
+	invoice := billing.Namespace("invoice")
+
+	var registry FunctionRegistry = invoice
+	root.RegisterFunction("inc", newIntFunction(t, func(i int) int { return i + 1 }))
+	billing.RegisterFunction("inc", newIntFunction(t, func(i int) int { return i + 10 }))
+	registry.RegisterFunction("double", newIntFunction(t, func(i int) int { return i * 2 }))
+
+	// 가까운 namespace 부터 찾음
+	if node, ok := invoice.GetFunctionNode("inc"); !ok || node.ID != "billing.inc" {
+		t.Errorf("GetFunctionNode(inc) = %v, want billing.inc", node)
+	}
+	if node, ok := root.GetFunctionNode("billing.invoice.double"); !ok || node.ID != "billing.invoice.double" {
+		t.Errorf("GetFunctionNode(billing.invoice.double) = %v", node)
+	}
+	if _, ok := root.GetFunctionNode("double"); ok {
+		t.Error("GetFunctionNode() should not look up child namespace without prefix")
+	}
+
+	if err := invoice.ConnectFunctionNode("inc", "double"); err != nil {
+		t.Fatal(err)
+	}
+	if got := billing.FunctionIDs(); !reflect.DeepEqual(got, []string{"inc", "invoice.double"}) {
+		t.Errorf("FunctionIDs() = %v", got)
+	}
+
+	results, err := NewFunctionChainExecutor(invoice, nil).Execute(context.Background(), "inc", 1)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if last := results.Slice()[1]; last.NodeID != "billing.invoice.double" || last.Res != 22 {
+		t.Errorf("Execute() last = %s %v, want billing.invoice.double 22", last.NodeID, last.Res)
+	}
+
+	// 다른 registry 로 namespace 를 복사
+	dst := NewFunctionRegistry()
+	if err := billing.ExportTo(dst, "imported"); err != nil {
+		t.Fatalf("ExportTo() error = %v", err)
+	}
+	if got := dst.FunctionIDs(); !reflect.DeepEqual(got, []string{"imported.inc", "imported.invoice.double"}) {
+		t.Errorf("ExportTo() ids = %v", got)
+	}
+	if node, _ := dst.GetFunctionNode("imported.inc"); !node.Next.Exists("imported.invoice.double") {
+		t.Error("ExportTo() should copy connections in the namespace")
+	}
+}
+
+func TestNamespaceAccessHook(t *testing.T) {
+	errReadOnly := errors.New("read only")
+	root := NewNamespacedFunctionRegistry(NewFunctionRegistry())
+	billing := root.Namespace("billing")
+	billing.RegisterFunction("inc", newIntFunction(t, func(i int) int { return i + 1 }))
+	billing.AddAccessHook(func(op AccessOperation, namespace, name string) error {
+		if op == AccessRegister {
+			return errReadOnly
+		}
+		return nil
+	})
+
+	// 하위 namespace 에도 적용됨
+	if err := billing.Namespace("invoice").RegisterFunction("inc", newIntFunction(t, func(i int) int { return i })); !errors.Is(err, errReadOnly) {
+		t.Errorf("RegisterFunction() error = %v, want read only", err)
+	}
+	if err := root.RegisterFunction("inc", newIntFunction(t, func(i int) int { return i })); err != nil {
+		t.Errorf("RegisterFunction() in other namespace error = %v", err)
+	}
+	if _, ok := billing.GetFunctionNode("inc"); !ok {
+		t.Error("GetFunctionNode() should be allowed")
+	}
+}
+
+func TestNamespaceExportToFailure(t *testing.T) {
+	errDenied := errors.New("denied")
+	billing := NewNamespacedFunctionRegistry(NewFunctionRegistry()).Namespace("billing")
+	billing.RegisterFunction("inc", newIntFunction(t, func(i int) int { return i + 1 }))
+	billing.RegisterFunction("secret", newIntFunction(t, func(i int) int { return i }))
+	billing.RegisterFunction("zero", newIntFunction(t, func(i int) int { return 0 }))
+	if err := billing.ConnectFunctionNode("inc", "zero"); err != nil {
+		t.Fatal(err)
+	}
+
+	// export 권한이 없는 function 이 있으면 아무것도 복사하지 않음
+	billing.AddAccessHook(func(op AccessOperation, namespace, name string) error {
+		if op == AccessExport && name == "billing.secret" {
+			return errDenied
+		}
+		return nil
+	})
+	dst := NewFunctionRegistry()
+	if err := billing.ExportTo(dst, "imported"); !errors.Is(err, errDenied) {
+		t.Errorf("ExportTo() error = %v, want denied", err)
+	}
+	if got := dst.FunctionIDs(); len(got) != 0 {
+		t.Errorf("ExportTo() should not copy any function, got %v", got)
+	}
+
+	// 복사 중 실패하면 복사한 function 을 삭제하고, 원래 있던 function 은 남김
+	dst.RegisterFunction("imported.zero", newIntFunction(t, func(i int) int { return i }))
+	allowed := NewNamespacedFunctionRegistry(NewFunctionRegistry()).Namespace("billing")
+	allowed.RegisterFunction("inc", newIntFunction(t, func(i int) int { return i + 1 }))
+	allowed.RegisterFunction("zero", newIntFunction(t, func(i int) int { return 0 }))
+	if err := allowed.ExportTo(dst, "imported"); !errors.Is(err, ErrDuplicateFunction) {
+		t.Errorf("ExportTo() error = %v, want ErrDuplicateFunction", err)
+	}
+	if got := dst.FunctionIDs(); !reflect.DeepEqual(got, []string{"imported.zero"}) {
+		t.Errorf("ExportTo() should roll back copied functions, got %v", got)
+	}
+}
@@ -0,0 +1,143 @@
+package v2
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// nodeFlowKey | context 에 NodeFlow 를 저장할 때 사용하는 key
+// 문자열 key 는 다른 패키지의 key 와 충돌할 수 있으므로 unexported 타입을 사용함
+type nodeFlowKey struct{}
+
+// NodeStep | 실행 경로(NodeFlow) 의 한 단계
+// 같은 노드를 재시도하는 경우 Attempt 로 구분함 (1 부터 시작)
+type NodeStep struct {
+	NodeID  string
+	Attempt int
+}
+
+func (s NodeStep) String() string {
+	if s.Attempt <= 1 {
+		return s.NodeID
+	}
+	return fmt.Sprintf("%s#%d", s.NodeID, s.Attempt)
+}
+
+// NodeFlow | 최상위 실행부터 현재 노드까지의 실행 경로
+// Steps 의 마지막 요소가 현재 실행 중인 노드임
+type NodeFlow struct {
+	RootExecutionID string
+	Steps           []NodeStep
+}
+
+// Depth | 실행 경로의 깊이를 반환합니다. (루트 노드 = 1)
+func (f NodeFlow) Depth() int {
+	return len(f.Steps)
+}
+
+// Current | 현재 실행 중인 노드를 반환합니다.
+func (f NodeFlow) Current() (NodeStep, bool) {
+	if len(f.Steps) == 0 {
+		return NodeStep{}, false
+	}
+	return f.Steps[len(f.Steps)-1], true
+}
+
+// Parent | 현재 노드를 호출한 부모 노드를 반환합니다.
+func (f NodeFlow) Parent() (NodeStep, bool) {
+	if len(f.Steps) < 2 {
+		return NodeStep{}, false
+	}
+	return f.Steps[len(f.Steps)-2], true
+}
+
+// Root | 실행 경로의 첫 노드를 반환합니다.
+func (f NodeFlow) Root() (NodeStep, bool) {
+	if len(f.Steps) == 0 {
+		return NodeStep{}, false
+	}
+	return f.Steps[0], true
+}
+
+// IDs | 실행 경로의 NodeID 를 순서대로 반환합니다.
+func (f NodeFlow) IDs() []string {
+	ids := make([]string, 0, len(f.Steps))
+	for _, step := range f.Steps {
+		ids = append(ids, step.NodeID)
+	}
+	return ids
+}
+
+// String | "a/b#2/c" 형태로 실행 경로를 반환합니다.
+func (f NodeFlow) String() string {
+	ids := make([]string, len(f.Steps))
+	for i, step := range f.Steps {
+		ids[i] = step.String()
+	}
+	return strings.Join(ids, "/")
+}
+
+// WithNodeStep | ctx 의 실행 경로에 nodeID 를 추가한 새 context 를 반환합니다.
+// 실행 경로가 없으면 ctx 의 RunID 를, RunID 도 없으면 새 ID 를 RootExecutionID 로 사용합니다.
+func WithNodeStep(ctx context.Context, nodeID string, attempt int) context.Context {
+	flow, ok := GetNodeFlow(ctx)
+	if !ok {
+		if flow.RootExecutionID = GetRunID(ctx); flow.RootExecutionID == "" {
+			flow.RootExecutionID = newExecutionID()
+		}
+	}
+
+	// 분기된 다른 경로와 slice 를 공유하지 않도록 복사함
+	steps := make([]NodeStep, len(flow.Steps), len(flow.Steps)+1)
+	copy(steps, flow.Steps)
+	flow.Steps = append(steps, NodeStep{NodeID: nodeID, Attempt: attempt})
+
+	return context.WithValue(ctx, nodeFlowKey{}, flow)
+}
+
+// SetNodeFlowInContext | 첫 번째 시도로 nodeID 를 실행 경로에 추가합니다.
+func SetNodeFlowInContext(ctx context.Context, next string) context.Context {
+	return WithNodeStep(ctx, next, 1)
+}
+
+// GetNodeFlow | ctx 에 저장된 실행 경로를 반환합니다.
+func GetNodeFlow(ctx context.Context) (NodeFlow, bool) {
+	flow, ok := ctx.Value(nodeFlowKey{}).(NodeFlow)
+	return flow, ok
+}
+
+// GetNodeFlowInContext | ctx 에 저장된 실행 경로를 문자열로 반환합니다.
+func GetNodeFlowInContext(ctx context.Context) string {
+	flow, _ := GetNodeFlow(ctx)
+	return flow.String()
+}
+
+// GetNodeFlowDepth | ctx 에 저장된 실행 경로의 깊이를 반환합니다.
+func GetNodeFlowDepth(ctx context.Context) int {
+	flow, _ := GetNodeFlow(ctx)
+	return flow.Depth()
+}
+
+// GetParentNodeStep | ctx 에 저장된 실행 경로의 부모 노드를 반환합니다.
+func GetParentNodeStep(ctx context.Context) (NodeStep, bool) {
+	flow, _ := GetNodeFlow(ctx)
+	return flow.Parent()
+}
+
+// GetRootExecutionID | ctx 에 저장된 실행 경로의 RootExecutionID 를 반환합니다.
+func GetRootExecutionID(ctx context.Context) string {
+	flow, _ := GetNodeFlow(ctx)
+	return flow.RootExecutionID
+}
+
+// newExecutionID | 랜덤한 16 byte hex 문자열 ID 를 생성합니다.
+func newExecutionID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to generate execution id: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
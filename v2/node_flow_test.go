@@ -0,0 +1,59 @@
+package v2
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNodeFlowInContext(t *testing.T) {
+	ctx := context.Background()
+	if flow := GetNodeFlowInContext(ctx); flow != "" {
+		t.Errorf("GetNodeFlowInContext() = %s, want empty", flow)
+	}
+	if _, ok := GetParentNodeStep(ctx); ok {
+		t.Errorf("GetParentNodeStep() should not exist in empty context")
+	}
+
+	ctx = SetNodeFlowInContext(ctx, "a")
+	rootID := GetRootExecutionID(ctx)
+	if rootID == "" {
+		t.Errorf("GetRootExecutionID() should not be empty")
+	}
+
+	ctx = WithNodeStep(ctx, "b", 2)
+	if flow := GetNodeFlowInContext(ctx); flow != "a/b#2" {
+		t.Errorf("GetNodeFlowInContext() = %s, want a/b#2", flow)
+	}
+	if depth := GetNodeFlowDepth(ctx); depth != 2 {
+		t.Errorf("GetNodeFlowDepth() = %d, want 2", depth)
+	}
+	parent, ok := GetParentNodeStep(ctx)
+	if !ok || parent.NodeID != "a" {
+		t.Errorf("GetParentNodeStep() = %v, want a", parent)
+	}
+	if id := GetRootExecutionID(ctx); id != rootID {
+		t.Errorf("GetRootExecutionID() = %s, want %s", id, rootID)
+	}
+}
+
+// 분기된 경로끼리 Steps 를 공유하지 않는지 검증
+func TestNodeFlowBranch(t *testing.T) {
+	ctx := SetNodeFlowInContext(context.Background(), "root")
+	ctx = SetNodeFlowInContext(ctx, "mid")
+
+	left := SetNodeFlowInContext(ctx, "left")
+	right := SetNodeFlowInContext(ctx, "right")
+
+	if flow := GetNodeFlowInContext(left); flow != "root/mid/left" {
+		t.Errorf("left flow = %s, want root/mid/left", flow)
+	}
+	if flow := GetNodeFlowInContext(right); flow != "root/mid/right" {
+		t.Errorf("right flow = %s, want root/mid/right", flow)
+	}
+
+	// 문자열 key 와 충돌하지 않아야 함
+	ctx = context.WithValue(ctx, "nodeFlow", "other")
+	if flow := GetNodeFlowInContext(ctx); flow != "root/mid" {
+		t.Errorf("flow = %s, want root/mid", flow)
+	}
+}
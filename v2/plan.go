@@ -0,0 +1,191 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PlanKind | 실행 계획에서 각 노드의 종류
+// v2 는 function, converter 만 사용하며, 다른 패키지는 자신의 종류를 PlanKind 로 정의해 사용할 수 있습니다.
+type PlanKind string
+
+const (
+	PlanFunction  = PlanKind("function")
+	PlanConverter = PlanKind("converter")
+)
+
+// PlanNode | 실행하지 않고 구조만 따라가며 만든 실행 계획
+// ReqType, ResType 은 정적으로 알 수 있을 때만 채워지며, Parallel 이면 Children 이 동시에 실행됨
+type PlanNode struct {
+	Kind     PlanKind
+	Name     string
+	ReqType  reflect.Type
+	ResType  reflect.Type
+	Parallel bool
+	Children []*PlanNode
+}
+
+// String | 실행 순서대로 들여쓰기 된 계획을 반환합니다.
+func (p *PlanNode) String() string {
+	var sb strings.Builder
+	p.write(&sb, 0)
+	return sb.String()
+}
+
+func (p *PlanNode) write(sb *strings.Builder, depth int) {
+	sb.WriteString(strings.Repeat("  ", depth))
+	sb.WriteString(fmt.Sprintf("%s %s", p.Kind, p.Name))
+	if p.ReqType != nil || p.ResType != nil {
+		sb.WriteString(fmt.Sprintf(" (%s -> %s)", typeName(p.ReqType), typeName(p.ResType)))
+	}
+	if p.Parallel {
+		sb.WriteString(fmt.Sprintf(" [parallel x%d]", len(p.Children)))
+	}
+	sb.WriteString("\n")
+	for _, child := range p.Children {
+		child.write(sb, depth+1)
+	}
+}
+
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return "?"
+	}
+	return t.String()
+}
+
+// PlanGraph | startID 부터 FunctionChainExecutor 가 실행할 순서대로 실행 계획을 만듭니다.
+// 노드/adapter 의 요청, 응답 타입이 맞지 않거나 순환 연결이 있으면 모든 문제를 모아 에러로 반환합니다.
+func PlanGraph(registry FunctionRegistry, startID string) (*PlanNode, error) {
+	var errs []error
+	plan := planNode(registry, startID, nil, &errs)
+	return plan, errors.Join(errs...)
+}
+
+func planNode(registry FunctionRegistry, nodeID string, path []string, errs *[]error) *PlanNode {
+	node, ok := registry.GetFunctionNode(nodeID)
+	if !ok {
+		*errs = append(*errs, &FunctionNotFoundError{Name: nodeID})
+		return &PlanNode{Kind: PlanFunction, Name: nodeID}
+	}
+	plan := &PlanNode{
+		Kind:    PlanFunction,
+		Name:    nodeID,
+		ReqType: node.Function.GetRequestType(),
+		ResType: node.Function.GetResponseType(),
+	}
+	for _, id := range path {
+		if id == nodeID {
+			*errs = append(*errs, &CycleError{Name: nodeID, Path: path})
+			return plan
+		}
+	}
+	path = append(path[:len(path):len(path)], nodeID)
+
+	for _, nextID := range node.NextIDs() {
+		prevType, prevName := plan.ResType, nodeID
+		for i, adapter := range node.Adapters[nextID] {
+			name := fmt.Sprintf("%s->%s[%d]", nodeID, nextID, i)
+			checkPlanType(prevName, prevType, name, adapter.GetRequestType(), errs)
+			plan.Children = append(plan.Children, &PlanNode{
+				Kind:    PlanConverter,
+				Name:    name,
+				ReqType: adapter.GetRequestType(),
+				ResType: adapter.GetResponseType(),
+			})
+			prevType, prevName = adapter.GetResponseType(), name
+		}
+		child := planNode(registry, nextID, path, errs)
+		checkPlanType(prevName, prevType, nextID, child.ReqType, errs)
+		plan.Children = append(plan.Children, child)
+	}
+	return plan
+}
+
+func checkPlanType(fromName string, from reflect.Type, toName string, to reflect.Type, errs *[]error) {
+	if from == nil || to == nil {
+		return
+	}
+	if !from.AssignableTo(to) {
+		*errs = append(*errs, &TypeMismatchError{From: fromName, FromType: from, To: toName, ToType: to})
+	}
+}
+
+// SimulateFunc | dry-run 시 실제 function 대신 호출되는 stub
+type SimulateFunc func(ctx context.Context, req any) (any, error)
+
+// DryRun | 실제 function 을 호출하지 않고 실행 흐름만 따라가기 위한 설정
+// stub 이 없는 function 은 응답 타입의 zero value 를, 응답 타입을 모르면 요청을 그대로 반환합니다.
+// converter 의 stub 이름은 v2 는 "from->to[i]", v3 는 step 이름입니다.
+// converter 는 stub 이 없으면 simulated 입력을 받아 실제로 실행되므로, zero value 등 simulated 입력을 처리하지 못하는 converter 는 Simulate 로 stub 을 등록해야 합니다.
+type DryRun struct {
+	stubs map[string]SimulateFunc
+}
+
+func NewDryRun() *DryRun {
+	return &DryRun{stubs: make(map[string]SimulateFunc)}
+}
+
+// Simulate | nodeID 의 function 대신 호출할 stub 을 등록합니다.
+func (d *DryRun) Simulate(nodeID string, fn SimulateFunc) *DryRun {
+	d.stubs[nodeID] = fn
+	return d
+}
+
+// dryRunKey | context 에 DryRun 을 저장할 때 사용하는 key
+type dryRunKey struct{}
+
+// WithDryRun | dry-run 으로 실행하도록 설정한 context 를 반환합니다.
+func WithDryRun(ctx context.Context, dryRun *DryRun) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, dryRun)
+}
+
+// IsDryRun | ctx 가 dry-run 으로 설정되어 있는지 확인합니다.
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(*DryRun)
+	return dryRun != nil
+}
+
+// SimulatedOutput | dry-run 중이라면 nodeID 의 function 대신 사용할 output 을 반환합니다.
+// ok 가 false 라면 function 을 실제로 실행해야 합니다.
+func SimulatedOutput(ctx context.Context, nodeID string, resType reflect.Type, req any) (res any, ok bool, err error) {
+	dryRun, _ := ctx.Value(dryRunKey{}).(*DryRun)
+	if dryRun == nil {
+		return nil, false, nil
+	}
+	if stub, exists := dryRun.stubs[nodeID]; exists {
+		res, err = stub(ctx, req)
+		return res, true, err
+	}
+	if resType != nil {
+		return zeroValueWithType(resType), true, nil
+	}
+	return req, true, nil
+}
+
+// SimulatedConverterOutput | dry-run 중 name 의 converter 에 stub 이 등록되어 있다면 stub 의 output 을 반환합니다.
+// converter 는 대부분 순수한 변환이므로 stub 이 없으면 dry-run 중에도 실제로 실행합니다.
+func SimulatedConverterOutput(ctx context.Context, name string, req any) (res any, ok bool, err error) {
+	dryRun, _ := ctx.Value(dryRunKey{}).(*DryRun)
+	if dryRun == nil {
+		return nil, false, nil
+	}
+	stub, exists := dryRun.stubs[name]
+	if !exists {
+		return nil, false, nil
+	}
+	res, err = stub(ctx, req)
+	return res, true, err
+}
+
+// SubstitutedOutput | function 을 실행하기 전, Replay 또는 DryRun 으로 대체할 output 이 있는지 확인합니다.
+// Replay 가 DryRun 보다 우선합니다.
+func SubstitutedOutput(ctx context.Context, nodeID string, resType reflect.Type, req any) (res any, ok bool, err error) {
+	if res, ok, err = ReplayedOutput(ctx, nodeID, resType); ok {
+		return res, ok, err
+	}
+	return SimulatedOutput(ctx, nodeID, resType, req)
+}
@@ -0,0 +1,73 @@
+package v2
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPlanGraph(t *testing.T) {
+	registry := NewFunctionRegistry()
+	registry.RegisterFunction("inc", newIntFunction(t, func(i int) int { return i + 1 }))
+	registry.RegisterFunction("double", newIntFunction(t, func(i int) int { return i * 2 }))
+	toString, _ := NewAnyFunction(reflect.TypeOf(0), reflect.TypeOf(""), func(ctx context.Context, req any) (any, error) {
+		return strconv.Itoa(req.(int)), nil
+	})
+	registry.RegisterFunction("toString", toString)
+	if err := registry.ConnectFunctionNode("inc", "double"); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.ConnectFunctionNode("inc", "toString"); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := PlanGraph(registry, "inc")
+	if err != nil {
+		t.Fatalf("PlanGraph() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"function inc (int -> int)",
+		"  function double (int -> int)",
+		"  function toString (int -> string)",
+		"",
+	}, "\n")
+	if plan.String() != want {
+		t.Errorf("PlanGraph() =\n%s\nwant\n%s", plan, want)
+	}
+
+	// 연결 이후 노드가 교체되어 타입이 맞지 않게 된 경우
+	node, _ := registry.GetFunctionNode("double")
+	node.Function = toString
+	node.Next.Add("inc")
+	if _, err := PlanGraph(registry, "inc"); err == nil ||
+		!strings.Contains(err.Error(), "type mismatch") || !strings.Contains(err.Error(), "already in the flow") {
+		t.Errorf("PlanGraph() error = %v, want type mismatch and cycle", err)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	called := false
+	registry := NewFunctionRegistry()
+	registry.RegisterFunction("charge", newIntFunction(t, func(i int) int { called = true; return i }))
+	registry.RegisterFunction("notify", newIntFunction(t, func(i int) int { called = true; return i }))
+	if err := registry.ConnectFunctionNode("charge", "notify"); err != nil {
+		t.Fatal(err)
+	}
+
+	dryRun := NewDryRun().Simulate("charge", func(ctx context.Context, req any) (any, error) {
+		return req.(int) * 100, nil
+	})
+	results, err := NewFunctionChainExecutor(registry, nil).Execute(WithDryRun(context.Background(), dryRun), "charge", 3)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if called {
+		t.Errorf("real functions should not be called in dry-run")
+	}
+	rs := results.Slice()
+	if rs[0].Res != 300 || rs[1].Res != 0 {
+		t.Errorf("dry-run results = (%v, %v), want (300, 0)", rs[0].Res, rs[1].Res)
+	}
+}
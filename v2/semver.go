@@ -0,0 +1,245 @@
+package v2
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version | "major.minor.patch[-prerelease]" 형태의 semantic version
+type Version struct {
+	Major, Minor, Patch int
+	PreRelease          string
+}
+
+// ParseVersion | "1.2.0", "v1.2.0", "1.2.0-beta" 형태의 문자열을 Version 으로 변환합니다.
+// minor, patch 는 생략할 수 있으며 생략하면 0 입니다.
+func ParseVersion(s string) (Version, error) {
+	var v Version
+	raw := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	core, pre, _ := strings.Cut(raw, "-")
+	v.PreRelease = pre
+
+	parts := strings.Split(core, ".")
+	if core == "" || len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version '%s'", s)
+	}
+	nums := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version '%s'", s)
+		}
+		*nums[i] = n
+	}
+	return v, nil
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.PreRelease != "" {
+		s += "-" + v.PreRelease
+	}
+	return s
+}
+
+// Compare | v 가 o 보다 작으면 -1, 같으면 0, 크면 1 을 반환합니다. pre-release 는 정식 버전보다 작습니다.
+func (v Version) Compare(o Version) int {
+	if c := v.compareCore(o); c != 0 {
+		return c
+	}
+	switch {
+	case v.PreRelease == o.PreRelease:
+		return 0
+	case v.PreRelease == "":
+		return 1
+	case o.PreRelease == "":
+		return -1
+	default:
+		return comparePreRelease(v.PreRelease, o.PreRelease)
+	}
+}
+
+// compareCore | pre-release 를 제외한 major.minor.patch 만 비교합니다.
+func (v Version) compareCore(o Version) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// comparePreRelease | '.' 으로 구분된 식별자를 앞에서부터 비교합니다. (SemVer §11)
+// 숫자로만 된 식별자는 숫자로 비교하며 문자가 섞인 식별자보다 작고, 앞 식별자가 모두 같으면 식별자가 적은 쪽이 작습니다.
+// ex) alpha < alpha.1 < alpha.beta < beta < beta.2 < beta.11 < rc.1
+func comparePreRelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		case as[i] != bs[i]:
+			if as[i] < bs[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// VersionConstraint | 공백으로 구분된 조건을 모두 만족하는 버전을 고르는 제약
+// 지원하는 조건 : "1.2.0"(=), "=1.2.0", ">1.2.0", ">=1.2.0", "<2.0.0", "<=1.2.0", "^1.2.0", "~1.2.0", "*"
+type VersionConstraint struct {
+	raw   string
+	match []func(Version) bool
+}
+
+// ParseVersionConstraint | 문자열을 VersionConstraint 로 변환합니다.
+func ParseVersionConstraint(s string) (VersionConstraint, error) {
+	c := VersionConstraint{raw: s}
+	for _, term := range strings.Fields(s) {
+		if term == "*" || term == "latest" {
+			continue
+		}
+		op := strings.TrimRight(term, "0123456789.v-abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+		v, err := ParseVersion(term[len(op):])
+		if err != nil {
+			return VersionConstraint{}, fmt.Errorf("invalid version constraint '%s': %w", s, err)
+		}
+		var match func(Version) bool
+		switch op {
+		case "", "=":
+			match = func(o Version) bool { return o.Compare(v) == 0 }
+		case ">":
+			match = func(o Version) bool { return o.Compare(v) > 0 }
+		case ">=":
+			match = func(o Version) bool { return o.Compare(v) >= 0 }
+		case "<": // "<2.0.0" 은 2.0.0 의 pre-release 도 포함하지 않음
+			match = func(o Version) bool {
+				if v.PreRelease == "" && o.PreRelease != "" && o.compareCore(v) == 0 {
+					return false
+				}
+				return o.Compare(v) < 0
+			}
+		case "<=":
+			match = func(o Version) bool { return o.Compare(v) <= 0 }
+		case "^": // 0 이 아닌 가장 왼쪽 자리가 같은 범위에서 v 이상 ex) ^1.2.0 : <2.0.0, ^0.2.0 : <0.3.0, ^0.0.3 : <0.0.4, ^0 : <1.0.0
+			parts := versionParts(term[len(op):])
+			match = func(o Version) bool { return caretCompatible(v, parts, o) && o.Compare(v) >= 0 }
+		case "~": // 같은 major.minor 안에서 v 이상
+			match = func(o Version) bool { return o.Major == v.Major && o.Minor == v.Minor && o.Compare(v) >= 0 }
+		default:
+			return VersionConstraint{}, fmt.Errorf("invalid version constraint '%s': unknown operator '%s'", s, op)
+		}
+		c.match = append(c.match, match)
+	}
+	return c, nil
+}
+
+// caretCompatible | o 가 v 와 호환되는 범위인지 확인합니다.
+// 1.0.0 이전에는 minor (0.0.x 는 patch) 가 바뀌면 호환되지 않는 것으로 보며, 생략된 자리는 비교하지 않습니다.
+// parts 는 제약에 적힌 자리 수입니다. ex) "^0" : 1, "^0.2" : 2
+func caretCompatible(v Version, parts int, o Version) bool {
+	switch {
+	case v.Major != 0 || parts == 1:
+		return o.Major == v.Major
+	case v.Minor != 0 || parts == 2:
+		return o.Major == v.Major && o.Minor == v.Minor
+	default:
+		return o.Major == v.Major && o.Minor == v.Minor && o.Patch == v.Patch
+	}
+}
+
+// versionParts | "0.2", "v1.2.0-beta" 처럼 적힌 버전의 major.minor.patch 자리 수를 반환합니다.
+func versionParts(s string) int {
+	core, _, _ := strings.Cut(strings.TrimPrefix(s, "v"), "-")
+	return strings.Count(core, ".") + 1
+}
+
+// Match | v 가 모든 조건을 만족하는지 확인합니다.
+func (c VersionConstraint) Match(v Version) bool {
+	for _, match := range c.match {
+		if !match(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c VersionConstraint) String() string {
+	return c.raw
+}
+
+// SplitVersionedName | "add@1.2.0" 을 ("add", "1.2.0") 으로 나눕니다. '@' 가 없으면 version 은 빈 문자열입니다.
+func SplitVersionedName(s string) (name, version string) {
+	name, version, _ = strings.Cut(s, "@")
+	return name, version
+}
+
+// VersionedName | name 과 version 을 "add@1.2.0" 형태로 합칩니다. version 이 비어 있으면 name 만 반환합니다.
+func VersionedName(name, version string) string {
+	if version == "" {
+		return name
+	}
+	return name + "@" + version
+}
+
+// NormalizeVersionedName | "add@v1.2" 를 "add@1.2.0" 처럼 버전을 정규화한 이름으로 반환합니다.
+func NormalizeVersionedName(s string) (string, error) {
+	name, version := SplitVersionedName(s)
+	if name == "" {
+		return "", fmt.Errorf("invalid name '%s'", s)
+	}
+	if version == "" {
+		return name, nil
+	}
+	v, err := ParseVersion(version)
+	if err != nil {
+		return "", err
+	}
+	return VersionedName(name, v.String()), nil
+}
+
+// ResolveVersion | versions 중 constraint 를 만족하는 가장 높은 버전을 반환합니다.
+// constraint 가 비어 있으면 가장 높은 버전을 반환하며, 버전이 아닌 값(빈 문자열 등)은 무시합니다.
+func ResolveVersion(versions []string, constraint string) (string, error) {
+	c, err := ParseVersionConstraint(constraint)
+	if err != nil {
+		return "", err
+	}
+	parsed := make([]Version, 0, len(versions))
+	for _, s := range versions {
+		if v, err := ParseVersion(s); err == nil && c.Match(v) {
+			parsed = append(parsed, v)
+		}
+	}
+	if len(parsed) == 0 {
+		return "", fmt.Errorf("no version matches '%s'", constraint)
+	}
+	sort.Slice(parsed, func(i, j int) bool { return parsed[i].Compare(parsed[j]) > 0 })
+	return parsed[0].String(), nil
+}
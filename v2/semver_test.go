@@ -0,0 +1,72 @@
+package v2
+
+import "testing"
+
+func TestVersionConstraint(t *testing.T) {
+	versions := []string{"1.0.0", "1.2.0", "1.2.5", "2.0.0-beta", "2.0.0"}
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"", "2.0.0"},
+		{"1.2.0", "1.2.0"},
+		{"v1.2", "1.2.0"},
+		{"^1.0.0", "1.2.5"},
+		{"~1.2.0", "1.2.5"},
+		{">=1.0.0 <1.2.5", "1.2.0"},
+		{"<2.0.0", "1.2.5"},
+		{"<=2.0.0-beta", "2.0.0-beta"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			got, err := ResolveVersion(versions, tt.constraint)
+			if err != nil || got != tt.want {
+				t.Errorf("ResolveVersion(%q) = (%s, %v), want %s", tt.constraint, got, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := ResolveVersion(versions, "^3.0.0"); err == nil {
+		t.Error("ResolveVersion() should fail when nothing matches")
+	}
+	if _, err := ParseVersionConstraint("!1.0.0"); err == nil {
+		t.Error("ParseVersionConstraint() should fail with unknown operator")
+	}
+	if _, err := ParseVersion("1.x"); err == nil {
+		t.Error("ParseVersion() should fail with invalid version")
+	}
+}
+
+func TestVersionConstraintBeforeOne(t *testing.T) {
+	versions := []string{"0.0.3", "0.0.4", "0.2.0", "0.2.7", "0.9.0"}
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"^0.2.0", "0.2.7"},
+		{"^0.0.3", "0.0.3"},
+		{"^0", "0.9.0"},
+		{"^0.0", "0.0.4"},
+	}
+	for _, tt := range tests {
+		got, err := ResolveVersion(versions, tt.constraint)
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveVersion(%q) = (%s, %v), want %s", tt.constraint, got, err, tt.want)
+		}
+	}
+}
+
+func TestVersionComparePreRelease(t *testing.T) {
+	// SemVer §11 의 예시 순서
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0"}
+	for i := 0; i < len(ordered)-1; i++ {
+		a, _ := ParseVersion(ordered[i])
+		b, _ := ParseVersion(ordered[i+1])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("%s should be less than %s", a, b)
+		}
+	}
+	if got, _ := ResolveVersion([]string{"1.0.0-beta.2", "1.0.0-beta.11"}, ""); got != "1.0.0-beta.11" {
+		t.Errorf("ResolveVersion() = %s, want 1.0.0-beta.11", got)
+	}
+}
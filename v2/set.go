@@ -27,3 +27,11 @@ func (s *set[T]) GetElems() []T {
 	}
 	return rts
 }
+
+func (s *set[T]) clone() set[T] {
+	c := set[T]{make(map[T]struct{}, len(s.m))}
+	for k := range s.m {
+		c.m[k] = struct{}{}
+	}
+	return c
+}
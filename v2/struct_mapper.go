@@ -0,0 +1,308 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// structMapperTag | StructMapper 가 읽는 struct tag 이름
+// ex) `fd:"num2"` : 이 field 를 대상 struct 의 num2 에 복사
+//
+//	`fd:"result->num2"` : 원본 struct 의 result 를 대상 struct 의 num2 에 복사 (원본, 대상 어느 struct 에 적어도 됨)
+//	`fd:"-"` : 이름이 같아도 복사하지 않음
+const structMapperTag = "fd"
+
+// FieldMapping | 원본 field 에서 대상 field 로 값을 복사하는 규칙
+// field 이름은 대소문자를 구분하지 않으며, "Input.Num1" 처럼 '.' 으로 중첩된 struct 의 field 를 지정할 수 있습니다.
+type FieldMapping struct {
+	From string
+	To   string
+}
+
+type resolvedMapping struct {
+	from    []int
+	to      []int
+	convert bool // 타입이 달라 reflect.Value.Convert 가 필요한 경우
+}
+
+// StructMapper | 원본 struct 의 field 를 대상 struct 로 복사하는 converter
+// field 대응 규칙은 NewStructMapper 에서 한 번만 검사합니다.
+type StructMapper struct {
+	srcType  reflect.Type
+	dstType  reflect.Type
+	mappings []resolvedMapping
+}
+
+// NewStructMapper | src 타입의 값을 dst 타입으로 복사하는 StructMapper 를 만듭니다.
+// 대응 규칙의 우선순위는 explicit > struct tag > 같은 이름 순이며,
+// 존재하지 않는 field 나 타입이 맞지 않는 field 가 있으면 에러를 반환합니다.
+// 숫자 field 는 값이 바뀌지 않는 변환(int32 -> int64 등)만 허용하며, 줄어드는 변환은 explicit 규칙으로만 지정할 수 있습니다.
+func NewStructMapper(src, dst reflect.Type, explicit ...FieldMapping) (*StructMapper, error) {
+	srcStruct, err := structElem(src)
+	if err != nil {
+		return nil, fmt.Errorf("source %w", err)
+	}
+	dstStruct, err := structElem(dst)
+	if err != nil {
+		return nil, fmt.Errorf("destination %w", err)
+	}
+
+	m := &StructMapper{srcType: src, dstType: dst}
+	mapped := make(map[string]bool) // 이미 값이 정해진 대상 field
+	ignored := make(map[string]bool)
+
+	add := func(rule string, fm FieldMapping) error {
+		from, fromType, err := resolveField(srcStruct, fm.From, true)
+		if err != nil {
+			return fmt.Errorf("%s mapping '%s->%s': source %w", rule, fm.From, fm.To, err)
+		}
+		to, toType, err := resolveField(dstStruct, fm.To, false)
+		if err != nil {
+			return fmt.Errorf("%s mapping '%s->%s': destination %w", rule, fm.From, fm.To, err)
+		}
+		key := fmt.Sprint(to)
+		if mapped[key] {
+			return nil
+		}
+		convert, err := fieldCompatible(fm, fromType, toType, rule == "explicit")
+		if err != nil {
+			return fmt.Errorf("%s mapping '%s->%s': %w", rule, fm.From, fm.To, err)
+		}
+		mapped[key] = true
+		m.mappings = append(m.mappings, resolvedMapping{from: from, to: to, convert: convert})
+		return nil
+	}
+
+	// 1. explicit
+	for _, fm := range explicit {
+		if err := add("explicit", fm); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. struct tag
+	for _, tm := range tagMappings(srcStruct, true) {
+		if tm.To == "-" {
+			ignored[strings.ToLower(tm.From)] = true
+			continue
+		}
+		if err := add("tag", tm); err != nil {
+			return nil, err
+		}
+	}
+	for _, tm := range tagMappings(dstStruct, false) {
+		if tm.From == "-" {
+			ignored[strings.ToLower(tm.To)] = true
+			continue
+		}
+		if err := add("tag", tm); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. 같은 이름
+	for i := 0; i < srcStruct.NumField(); i++ {
+		sf := srcStruct.Field(i)
+		if !sf.IsExported() || ignored[strings.ToLower(sf.Name)] {
+			continue
+		}
+		df, ok := dstStruct.FieldByName(sf.Name)
+		if !ok || !df.IsExported() || len(df.Index) != 1 || ignored[strings.ToLower(df.Name)] || mapped[fmt.Sprint(df.Index)] {
+			continue
+		}
+		if err := add("name", FieldMapping{From: sf.Name, To: df.Name}); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// NewStructMapperFor | 제네릭 타입으로 StructMapper 를 만듭니다.
+func NewStructMapperFor[Src any, Dst any](explicit ...FieldMapping) (*StructMapper, error) {
+	return NewStructMapper(GetGenericType[Src](), GetGenericType[Dst](), explicit...)
+}
+
+// Map | src 의 field 를 새 대상 값에 복사하여 반환합니다.
+func (m *StructMapper) Map(src any) (any, error) {
+	if !EqualType(m.srcType, src) {
+		return nil, fmt.Errorf("struct mapper expects %s but got %T", m.srcType, src)
+	}
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Kind() == reflect.Ptr {
+		if srcValue.IsNil() {
+			return nil, fmt.Errorf("struct mapper got nil %s", m.srcType)
+		}
+		srcValue = srcValue.Elem()
+	}
+
+	dstStruct, _ := structElem(m.dstType)
+	dstPtr := reflect.New(dstStruct)
+	dstValue := dstPtr.Elem()
+
+	for _, mapping := range m.mappings {
+		from, err := srcValue.FieldByIndexErr(mapping.from)
+		if err != nil {
+			return nil, fmt.Errorf("struct mapper: %w", err)
+		}
+		to := dstValue.FieldByIndex(mapping.to)
+		if mapping.convert {
+			from = from.Convert(to.Type())
+		}
+		to.Set(from)
+	}
+	if m.dstType.Kind() == reflect.Ptr {
+		return dstPtr.Interface(), nil
+	}
+	return dstValue.Interface(), nil
+}
+
+// Func | v3 의 converter 로 사용할 수 있는 func 을 반환합니다.
+func (m *StructMapper) Func() func(ctx context.Context, req any) (any, error) {
+	return func(_ context.Context, req any) (any, error) {
+		return m.Map(req)
+	}
+}
+
+// Adapter | ConnectFunctionNode 의 adapter 로 사용할 수 있는 AnyFunction 을 반환합니다.
+func (m *StructMapper) Adapter() (AnyFunction, error) {
+	return NewAnyFunction(m.srcType, m.dstType, m.Func())
+}
+
+func structElem(t reflect.Type) (reflect.Type, error) {
+	if t == nil {
+		return nil, fmt.Errorf("type must not be nil")
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("type must be struct or pointer to struct, got %s", t)
+	}
+	return t, nil
+}
+
+// resolveField | "A.B" 형태의 경로를 field index 로 변환합니다. 대소문자를 구분하지 않습니다.
+// followPtr 가 true 이면 중간 경로의 struct pointer 를 따라갑니다. (nil 이면 Map 에서 에러)
+func resolveField(t reflect.Type, path string, followPtr bool) ([]int, reflect.Type, error) {
+	var index []int
+	for _, name := range strings.Split(path, ".") {
+		if followPtr && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, nil, fmt.Errorf("field '%s' is not in a struct (%s)", path, t)
+		}
+		f, ok := t.FieldByName(name)
+		if !ok {
+			f, ok = t.FieldByNameFunc(func(n string) bool { return strings.EqualFold(n, name) })
+		}
+		if !ok || !f.IsExported() {
+			return nil, nil, fmt.Errorf("field '%s' not found in %s", path, t)
+		}
+		index = append(index, f.Index...)
+		t = f.Type
+	}
+	return index, t, nil
+}
+
+// fieldCompatible | from 값을 to field 에 넣을 수 있는지 확인합니다.
+// 숫자 타입끼리는 값이 보존되는 변환만 허용하며, explicit 이면 값이 잘리거나 넘칠 수 있는 변환도 허용합니다.
+// 허용하지 않는 숫자 변환은 TypeMismatchError 를 반환합니다.
+func fieldCompatible(fm FieldMapping, from, to reflect.Type, explicit bool) (convert bool, err error) {
+	if from.AssignableTo(to) {
+		return false, nil
+	}
+	if isNumberKind(from.Kind()) && isNumberKind(to.Kind()) {
+		if explicit || isWideningKind(from.Kind(), to.Kind()) {
+			return true, nil
+		}
+		return false, &TypeMismatchError{From: fm.From, FromType: from, To: fm.To, ToType: to}
+	}
+	if from.Kind() == to.Kind() && from.ConvertibleTo(to) {
+		return true, nil
+	}
+	return false, fmt.Errorf("type %s is not assignable to %s", from, to)
+}
+
+// isWideningKind | from 의 모든 값을 to 로 바꿔도 값이 바뀌지 않는지 확인합니다.
+// float 로는 가수부에 들어가는 크기(float32 : 16 bit, float64 : 32 bit)의 정수만 허용합니다.
+func isWideningKind(from, to reflect.Kind) bool {
+	fromBits, toBits := numberBits(from), numberBits(to)
+	switch {
+	case isFloatKind(from):
+		return isFloatKind(to) && fromBits <= toBits
+	case isFloatKind(to):
+		return fromBits <= toBits/2
+	case isUnsignedKind(from) == isUnsignedKind(to):
+		return fromBits <= toBits
+	case isUnsignedKind(from):
+		return fromBits < toBits
+	default:
+		return false // 음수를 담을 수 없음
+	}
+}
+
+func numberBits(k reflect.Kind) int {
+	switch k {
+	case reflect.Int8, reflect.Uint8:
+		return 8
+	case reflect.Int16, reflect.Uint16:
+		return 16
+	case reflect.Int32, reflect.Uint32, reflect.Float32:
+		return 32
+	case reflect.Int, reflect.Uint:
+		return strconv.IntSize
+	default:
+		return 64
+	}
+}
+
+func isUnsignedKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// tagMappings | struct tag 에 정의된 대응 규칙을 읽어옵니다.
+// isSource 가 true 이면 `fd:"num2"` 는 이 field -> num2, false 이면 num2 -> 이 field 로 해석합니다.
+// `fd:"-"` 는 From 또는 To 가 "-" 인 규칙으로 반환됩니다.
+func tagMappings(t reflect.Type, isSource bool) []FieldMapping {
+	mappings := make([]FieldMapping, 0)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup(structMapperTag)
+		if !ok || tag == "" {
+			continue
+		}
+		if from, to, found := strings.Cut(tag, "->"); found {
+			mappings = append(mappings, FieldMapping{From: strings.TrimSpace(from), To: strings.TrimSpace(to)})
+			continue
+		}
+		if isSource {
+			mappings = append(mappings, FieldMapping{From: f.Name, To: tag})
+		} else {
+			mappings = append(mappings, FieldMapping{From: tag, To: f.Name})
+		}
+	}
+	return mappings
+}
@@ -0,0 +1,163 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type mapperSource struct {
+	Result int
+	Name   string
+	Memo   string `fd:"-"`
+	Inner  struct{ Num1 int32 }
+}
+
+type mapperTarget struct {
+	Num1 int64
+	Num2 int `fd:"result->num2"`
+	Name string
+	Memo string
+}
+
+func TestStructMapper(t *testing.T) {
+	mapper, err := NewStructMapperFor[mapperSource, *mapperTarget](FieldMapping{From: "inner.num1", To: "Num1"})
+	if err != nil {
+		t.Fatalf("NewStructMapperFor() error = %v", err)
+	}
+	src := mapperSource{Result: 3, Name: "a", Memo: "skip"}
+	src.Inner.Num1 = 7
+	res, err := mapper.Map(src)
+	if err != nil {
+		t.Fatalf("Map() error = %v", err)
+	}
+	want := &mapperTarget{Num1: 7, Num2: 3, Name: "a"}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("Map() = %+v, want %+v", res, want)
+	}
+
+	if _, err := mapper.Map(1); err == nil {
+		t.Error("Map() with wrong type should fail")
+	}
+}
+
+func TestStructMapperValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping []FieldMapping
+		errText string
+	}{
+		{"unknown source", []FieldMapping{{From: "nope", To: "Num1"}}, "source field 'nope' not found"},
+		{"unknown destination", []FieldMapping{{From: "Result", To: "nope"}}, "destination field 'nope' not found"},
+		{"type mismatch", []FieldMapping{{From: "Name", To: "Num1"}}, "type string is not assignable to int64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewStructMapperFor[mapperSource, mapperTarget](tt.mapping...)
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("NewStructMapperFor() error = %v, want %q", err, tt.errText)
+			}
+		})
+	}
+
+	if _, err := NewStructMapperFor[int, mapperTarget](); err == nil {
+		t.Error("NewStructMapperFor() with non struct source should fail")
+	}
+}
+
+func TestStructMapperNumberConversion(t *testing.T) {
+	type numbers struct {
+		I8  int8
+		I32 int32
+		I64 int64
+		U8  uint8
+		U64 uint64
+		F32 float32
+		F64 float64
+	}
+	tests := []struct {
+		from, to string
+		ok       bool
+	}{
+		{"I8", "I64", true},
+		{"I32", "F64", true},
+		{"U8", "I32", true},
+		{"F32", "F64", true},
+		{"I64", "U8", false},
+		{"I64", "I32", false},
+		{"I64", "F64", false},
+		{"I8", "U64", false},
+		{"F64", "I64", false},
+		{"F64", "F32", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			src, _ := reflect.TypeOf(numbers{}).FieldByName(tt.from)
+			dst, _ := reflect.TypeOf(numbers{}).FieldByName(tt.to)
+			from := reflect.StructOf([]reflect.StructField{{Name: "V", Type: src.Type}})
+			to := reflect.StructOf([]reflect.StructField{{Name: "V", Type: dst.Type}})
+
+			_, err := NewStructMapper(from, to)
+			if tt.ok && err != nil {
+				t.Errorf("NewStructMapper() error = %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrTypeMismatch) {
+				t.Errorf("NewStructMapper() error = %v, want ErrTypeMismatch", err)
+			}
+			// explicit 규칙이면 줄어드는 변환도 허용
+			if _, err := NewStructMapper(from, to, FieldMapping{From: "V", To: "V"}); err != nil {
+				t.Errorf("NewStructMapper() with explicit mapping error = %v", err)
+			}
+		})
+	}
+
+	type ratio struct{ Value float64 }
+	type count struct{ Value int }
+	mapper, err := NewStructMapperFor[ratio, count](FieldMapping{From: "Value", To: "Value"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res, err := mapper.Map(ratio{Value: 2.7}); err != nil || res != (count{Value: 2}) {
+		t.Errorf("Map() = (%v, %v), want {2}", res, err)
+	}
+}
+
+func TestStructMapperAdapter(t *testing.T) {
+	type sumOutput struct{ Result int }
+	type doubleInput struct {
+		Num int `fd:"result"`
+	}
+
+	registry := NewFunctionRegistry()
+	sum, _ := NewAnyFunction(reflect.TypeOf(0), reflect.TypeOf(sumOutput{}), func(ctx context.Context, req any) (any, error) {
+		return sumOutput{Result: req.(int) + 1}, nil
+	})
+	double, _ := NewAnyFunction(reflect.TypeOf(doubleInput{}), reflect.TypeOf(0), func(ctx context.Context, req any) (any, error) {
+		return req.(doubleInput).Num * 2, nil
+	})
+	registry.RegisterFunction("sum", sum)
+	registry.RegisterFunction("double", double)
+
+	mapper, err := NewStructMapperFor[sumOutput, doubleInput]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	adapter, err := mapper.Adapter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.ConnectFunctionNode("sum", "double", adapter); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := NewFunctionChainExecutor(registry, nil).Execute(context.Background(), "sum", 1)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	last := results.Slice()[1]
+	if last.Res != 4 {
+		t.Errorf("double result = %v, want 4", last.Res)
+	}
+}
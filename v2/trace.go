@@ -0,0 +1,202 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// TraceKind | TraceEntry 가 function 호출인지 converter(adapter) 호출인지 구분
+type TraceKind string
+
+const (
+	TraceFunction  = TraceKind("function")
+	TraceConverter = TraceKind("converter")
+)
+
+// TraceEntry | 하나의 function/converter 호출 기록
+// Input, Output 은 JSON 으로 저장되며, JSON 으로 변환할 수 없는 값은 EncodeError 에 사유를 남김
+type TraceEntry struct {
+	Kind               TraceKind       `json:"kind"`
+	RunID              string          `json:"runId"`
+	InvocationID       string          `json:"invocationId"`
+	ParentInvocationID string          `json:"parentInvocationId,omitempty"`
+	NodeID             string          `json:"nodeId"`
+	NodeFlow           string          `json:"nodeFlow,omitempty"`
+	Input              json.RawMessage `json:"input,omitempty"`
+	Output             json.RawMessage `json:"output,omitempty"`
+	Error              string          `json:"error,omitempty"`
+	EncodeError        string          `json:"encodeError,omitempty"`
+	Start              time.Time       `json:"start"`
+	Duration           time.Duration   `json:"duration"`
+}
+
+// Trace | 하나의 실행에서 기록된 TraceEntry 목록
+type Trace struct {
+	Entries []TraceEntry `json:"entries"`
+}
+
+// Save | Trace 를 JSON 으로 w 에 기록합니다.
+func (t *Trace) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t)
+}
+
+// LoadTrace | Save 로 기록된 Trace 를 읽어옵니다.
+func LoadTrace(r io.Reader) (*Trace, error) {
+	var t Trace
+	if err := json.NewDecoder(r).Decode(&t); err != nil {
+		return nil, fmt.Errorf("failed to load trace: %w", err)
+	}
+	return &t, nil
+}
+
+// TraceRecorder | EventBus 에 등록하여 function/converter 호출을 Trace 로 기록하는 listener
+type TraceRecorder struct {
+	mu    sync.Mutex
+	trace Trace
+}
+
+func NewTraceRecorder() *TraceRecorder {
+	return &TraceRecorder{}
+}
+
+func (r *TraceRecorder) OnEvent(_ context.Context, e Event) {
+	var kind TraceKind
+	switch e.Type {
+	case NodeFinished:
+		kind = TraceFunction
+	case ConverterFinished, ConverterFailed:
+		kind = TraceConverter
+	default:
+		return
+	}
+
+	entry := TraceEntry{
+		Kind:               kind,
+		RunID:              e.RunID,
+		InvocationID:       e.InvocationID,
+		ParentInvocationID: e.ParentInvocationID,
+		NodeID:             e.NodeID,
+		NodeFlow:           e.NodeFlow,
+		Start:              e.Time.Add(-e.Duration),
+		Duration:           e.Duration,
+	}
+	if e.Err != nil {
+		entry.Error = e.Err.Error()
+	}
+
+	var encodeErrs []error
+	var err error
+	if entry.Input, err = json.Marshal(e.Req); err != nil {
+		encodeErrs = append(encodeErrs, fmt.Errorf("input: %w", err))
+		entry.Input = nil
+	}
+	if entry.Output, err = json.Marshal(e.Res); err != nil {
+		encodeErrs = append(encodeErrs, fmt.Errorf("output: %w", err))
+		entry.Output = nil
+	}
+	if err := errors.Join(encodeErrs...); err != nil {
+		entry.EncodeError = err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.trace.Entries = append(r.trace.Entries, entry)
+}
+
+// Trace | 지금까지 기록된 Trace 의 복사본을 반환합니다.
+func (r *TraceRecorder) Trace() *Trace {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := make([]TraceEntry, len(r.trace.Entries))
+	copy(entries, r.trace.Entries)
+	return &Trace{Entries: entries}
+}
+
+// Replay | 기록된 Trace 의 output 을 지정한 function 대신 반환하여 실행을 재현합니다.
+// 같은 NodeID 가 여러 번 호출되면 기록된 순서대로 output 을 반환합니다.
+type Replay struct {
+	mu          sync.Mutex
+	recorded    map[string][]TraceEntry
+	substitutes map[string]reflect.Type
+}
+
+func NewReplay(trace *Trace) *Replay {
+	recorded := make(map[string][]TraceEntry)
+	for _, entry := range trace.Entries {
+		if entry.Kind == TraceFunction {
+			recorded[entry.NodeID] = append(recorded[entry.NodeID], entry)
+		}
+	}
+	return &Replay{
+		recorded:    recorded,
+		substitutes: make(map[string]reflect.Type),
+	}
+}
+
+// Substitute | nodeID 의 function 을 실행하지 않고 기록된 output 으로 대체합니다.
+// typ 은 기록된 JSON output 을 decode 할 타입이며, nil 이면 실행기가 아는 타입(v2 의 응답 타입)으로 decode 합니다.
+// 실행기가 타입을 모르는 경우(v3 의 step 등)에는 typ 을 반드시 지정해야 합니다.
+func (r *Replay) Substitute(nodeID string, typ reflect.Type) *Replay {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.substitutes[nodeID] = typ
+	return r
+}
+
+// next | nodeID 의 다음 기록된 output 을 반환합니다.
+func (r *Replay) next(nodeID string, typ reflect.Type) (res any, ok bool, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subType, ok := r.substitutes[nodeID]
+	if !ok {
+		return nil, false, nil
+	}
+	entries := r.recorded[nodeID]
+	if len(entries) == 0 {
+		return nil, true, fmt.Errorf("no recorded output left for node '%s'", nodeID)
+	}
+	entry := entries[0]
+	r.recorded[nodeID] = entries[1:]
+
+	if entry.Error != "" {
+		return nil, true, errors.New(entry.Error)
+	}
+	if subType != nil {
+		typ = subType
+	}
+	if typ == nil {
+		return nil, true, fmt.Errorf("no type to decode recorded output of node '%s', pass one to Substitute", nodeID)
+	}
+	value := reflect.New(typ)
+	if err := json.Unmarshal(entry.Output, value.Interface()); err != nil {
+		return nil, true, fmt.Errorf("failed to decode recorded output of node '%s' as %s: %w", nodeID, typ, err)
+	}
+	return value.Elem().Interface(), true, nil
+}
+
+// replayKey | context 에 Replay 를 저장할 때 사용하는 key
+type replayKey struct{}
+
+// WithReplay | 실행 시 replay 에 지정된 function 을 기록된 output 으로 대체하도록 설정한 context 를 반환합니다.
+func WithReplay(ctx context.Context, replay *Replay) context.Context {
+	return context.WithValue(ctx, replayKey{}, replay)
+}
+
+// ReplayedOutput | ctx 의 Replay 에서 nodeID 로 대체할 output 을 찾습니다.
+// ok 가 false 라면 function 을 실제로 실행해야 합니다.
+func ReplayedOutput(ctx context.Context, nodeID string, typ reflect.Type) (res any, ok bool, err error) {
+	replay, _ := ctx.Value(replayKey{}).(*Replay)
+	if replay == nil {
+		return nil, false, nil
+	}
+	return replay.next(nodeID, typ)
+}
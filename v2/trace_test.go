@@ -0,0 +1,79 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTraceRecordAndReplay(t *testing.T) {
+	calls := 0
+	newRegistry := func(fail bool) FunctionRegistry {
+		registry := NewFunctionRegistry()
+		registry.RegisterFunction("inc", newIntFunction(t, func(i int) int { calls++; return i + 1 }))
+		double, _ := NewDecoratedFunctionBuilder[int, int]().
+			Func(func(ctx context.Context, req int) (int, error) {
+				if fail {
+					return 0, errors.New("external system down")
+				}
+				return req * 2, nil
+			}).Build().Any()
+		registry.RegisterFunction("double", double)
+		if err := registry.ConnectFunctionNode("inc", "double"); err != nil {
+			t.Fatal(err)
+		}
+		return registry
+	}
+
+	// 1. 정상 실행을 기록
+	recorder := NewTraceRecorder()
+	bus := NewEventBus()
+	bus.Subscribe(recorder)
+	ctx := WithEventBus(context.Background(), bus)
+	if _, err := NewFunctionChainExecutor(newRegistry(false), nil).Execute(ctx, "inc", 1); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := recorder.Trace().Save(&buf); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	trace, err := LoadTrace(&buf)
+	if err != nil {
+		t.Fatalf("LoadTrace() error = %v", err)
+	}
+	if len(trace.Entries) != 2 || trace.Entries[1].NodeFlow != "inc/double" || string(trace.Entries[1].Output) != "4" {
+		t.Fatalf("unexpected trace = %+v", trace.Entries)
+	}
+
+	// 2. double 이 실패하는 환경에서 기록된 output 으로 대체하여 재실행
+	calls = 0
+	replay := NewReplay(trace).Substitute("double", nil)
+	results, err := NewFunctionChainExecutor(newRegistry(true), nil).Execute(WithReplay(context.Background(), replay), "inc", 1)
+	if err != nil {
+		t.Fatalf("Execute() with replay error = %v", err)
+	}
+	rs := results.Slice()
+	if rs[1].Res != 4 {
+		t.Errorf("replayed result = %v (%T), want 4", rs[1].Res, rs[1].Res)
+	}
+	if calls != 1 {
+		t.Errorf("inc should be called once, got %d", calls)
+	}
+}
+
+func TestReplayRecordedError(t *testing.T) {
+	trace := &Trace{Entries: []TraceEntry{{Kind: TraceFunction, NodeID: "a", Error: "recorded failure"}}}
+	ctx := WithReplay(context.Background(), NewReplay(trace).Substitute("a", nil))
+
+	if _, ok, err := ReplayedOutput(ctx, "a", nil); !ok || err == nil || err.Error() != "recorded failure" {
+		t.Errorf("ReplayedOutput() = (%v, %v), want recorded failure", ok, err)
+	}
+	if _, ok, err := ReplayedOutput(ctx, "a", nil); !ok || err == nil {
+		t.Errorf("ReplayedOutput() should fail when no recorded output left")
+	}
+	if _, ok, _ := ReplayedOutput(ctx, "b", nil); ok {
+		t.Errorf("ReplayedOutput() should not substitute node 'b'")
+	}
+}
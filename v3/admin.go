@@ -0,0 +1,253 @@
+package v3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	v2 "func_decorator/v2"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AdminHandler | v2, v3 registry 를 운영하기 위한 http.Handler
+// GET  /functions       : v3 registry 의 function 과 v2 registry 의 노드 목록
+// GET  /graph           : v2 registry 의 노드와 연결 (v2.Graph)
+// POST /run/v3/{name}   : body 의 JSON 을 input 으로 v3 function 을 한 번 실행
+// POST /run/v2/{id}     : body 의 JSON 을 input 으로 v2 노드부터 연결된 노드를 실행
+// GET  /events[?run=ID] : 실행 Event 를 server-sent events 로 전달
+// 다른 경로에 붙일 때는 http.StripPrefix 를 사용합니다.
+type AdminHandler struct {
+	functions FunctionRegistry
+	graph     v2.FunctionRegistry
+	bus       *v2.EventBus
+	mux       *http.ServeMux
+}
+
+// NewAdminHandler | functions (v3) 와 graph (v2) 를 제공하는 AdminHandler 를 만듭니다. 사용하지 않는 registry 는 nil 로 둡니다.
+func NewAdminHandler(functions FunctionRegistry, graph v2.FunctionRegistry) *AdminHandler {
+	h := &AdminHandler{functions: functions, graph: graph, bus: v2.NewEventBus(), mux: http.NewServeMux()}
+	h.mux.HandleFunc("/functions", h.handleFunctions)
+	h.mux.HandleFunc("/graph", h.handleGraph)
+	h.mux.HandleFunc("/run/", h.handleRun)
+	h.mux.HandleFunc("/events", h.handleEvents)
+	return h
+}
+
+// EventBus | /events 로 전달되는 EventBus 를 반환합니다.
+// AdminHandler 밖의 실행도 v2.WithEventBus(ctx, h.EventBus()) 로 실행하면 /events 로 볼 수 있습니다.
+func (h *AdminHandler) EventBus() *v2.EventBus {
+	return h.bus
+}
+
+func (h *AdminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// AdminFunction | /functions 가 반환하는 function, Registry 는 "v2" 또는 "v3"
+type AdminFunction struct {
+	Registry string `json:"registry"`
+	FunctionInfo
+}
+
+// AdminNodeResult | v2 실행에서 노드 하나의 결과
+type AdminNodeResult struct {
+	NodeID   string `json:"nodeId"`
+	NodeFlow string `json:"nodeFlow"`
+	Output   any    `json:"output,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// AdminRunResult | /run 의 응답, v3 는 Output 을, v2 는 노드별 Results 를 반환합니다.
+type AdminRunResult struct {
+	RunID   string            `json:"runId"`
+	Output  json.RawMessage   `json:"output,omitempty"`
+	Results []AdminNodeResult `json:"results,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// AdminEvent | /events 로 전달되는 v2.Event, 요청과 응답은 포함하지 않습니다.
+type AdminEvent struct {
+	Type               v2.EventType `json:"type"`
+	RunID              string       `json:"runId"`
+	InvocationID       string       `json:"invocationId,omitempty"`
+	ParentInvocationID string       `json:"parentInvocationId,omitempty"`
+	NodeID             string       `json:"nodeId,omitempty"`
+	NodeFlow           string       `json:"nodeFlow,omitempty"`
+	Error              string       `json:"error,omitempty"`
+	Time               time.Time    `json:"time"`
+	Duration           string       `json:"duration,omitempty"`
+}
+
+func newAdminEvent(e v2.Event) AdminEvent {
+	event := AdminEvent{
+		Type:               e.Type,
+		RunID:              e.RunID,
+		InvocationID:       e.InvocationID,
+		ParentInvocationID: e.ParentInvocationID,
+		NodeID:             e.NodeID,
+		NodeFlow:           e.NodeFlow,
+		Time:               e.Time,
+	}
+	if e.Err != nil {
+		event.Error = e.Err.Error()
+	}
+	if e.Duration > 0 {
+		event.Duration = e.Duration.String()
+	}
+	return event
+}
+
+func (h *AdminHandler) handleFunctions(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	functions := make([]AdminFunction, 0)
+	if h.functions != nil {
+		for _, metadata := range h.functions.List() {
+			functions = append(functions, AdminFunction{Registry: "v3", FunctionInfo: metadata.Info()})
+		}
+	}
+	if h.graph != nil {
+		for _, node := range v2.DescribeGraph(h.graph).Nodes {
+			name, version := v2.SplitVersionedName(node.ID)
+			functions = append(functions, AdminFunction{Registry: "v2", FunctionInfo: FunctionInfo{
+				Name:       name,
+				Version:    version,
+				InputType:  node.InputType,
+				OutputType: node.OutputType,
+			}})
+		}
+	}
+	writeJSON(w, http.StatusOK, functions)
+}
+
+func (h *AdminHandler) handleGraph(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	if h.graph == nil {
+		writeJSON(w, http.StatusOK, v2.Graph{Nodes: []v2.GraphNode{}, Edges: []v2.GraphEdge{}})
+		return
+	}
+	writeJSON(w, http.StatusOK, v2.DescribeGraph(h.graph))
+}
+
+func (h *AdminHandler) handleRun(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+	registry, name, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/run/"), "/")
+	if name == "" || !(registry == "v3" && h.functions != nil || registry == "v2" && h.graph != nil) {
+		http.NotFound(w, r)
+		return
+	}
+	var input json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("function '%s': %w: %v", name, ErrInvalidInput, err))
+		return
+	}
+
+	ctx, _ := v2.StartRun(v2.WithEventBus(r.Context(), h.bus))
+	info, _ := v2.GetExecutionInfo(ctx)
+	result := AdminRunResult{RunID: info.RunID}
+	var err error
+	if registry == "v3" {
+		result.Output, err = h.runFunction(ctx, name, input)
+	} else {
+		result.Results, err = h.runGraph(ctx, name, input)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		writeJSON(w, remoteStatus(err), result)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// runFunction | v3 function 을 호출하고 NodeStarted, NodeFinished, RunFinished Event 를 발행합니다.
+func (h *AdminHandler) runFunction(ctx context.Context, name string, input json.RawMessage) (json.RawMessage, error) {
+	begin := time.Now()
+	v2.PublishEvent(ctx, v2.Event{Type: v2.NodeStarted, NodeID: name})
+	output, err := CallJSONFunction(ctx, h.functions, name, input)
+	v2.PublishEvent(ctx, v2.Event{Type: v2.NodeFinished, NodeID: name, Err: err, Duration: time.Since(begin)})
+	v2.PublishEvent(ctx, v2.Event{Type: v2.RunFinished, NodeID: name, Err: err, Duration: time.Since(begin)})
+	return output, err
+}
+
+// runGraph | input 을 시작 노드의 요청 타입으로 decode 해서 v2 그래프를 실행합니다.
+func (h *AdminHandler) runGraph(ctx context.Context, id string, input json.RawMessage) ([]AdminNodeResult, error) {
+	node, ok := h.graph.GetFunctionNode(id)
+	if !ok {
+		return nil, &v2.FunctionNotFoundError{Name: id}
+	}
+	req, err := DecodeJSON(input, node.Function.GetRequestType())
+	if err != nil {
+		return nil, fmt.Errorf("function '%s': %w: %v", id, ErrInvalidInput, err)
+	}
+
+	// ctx 에 이미 run 이 있으므로 RunFinished 는 직접 발행
+	begin := time.Now()
+	results, err := v2.NewFunctionChainExecutor(h.graph, nil).Execute(ctx, node.ID, req)
+	v2.PublishEvent(ctx, v2.Event{Type: v2.RunFinished, NodeID: node.ID, Err: err, Duration: time.Since(begin)})
+
+	nodes := make([]AdminNodeResult, 0)
+	for _, res := range results.Slice() {
+		result := AdminNodeResult{NodeID: res.NodeID, NodeFlow: res.NodeFlow, Output: res.Res}
+		if res.Err != nil {
+			result.Error = res.Err.Error()
+		}
+		nodes = append(nodes, result)
+	}
+	return nodes, err
+}
+
+// handleEvents | 연결이 끊길 때까지 Event 를 "event: {type}\ndata: {AdminEvent}" 형식으로 전달합니다.
+// 구독이 시작되면 ": subscribed" 주석을 먼저 보냅니다.
+func (h *AdminHandler) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+	run := r.URL.Query().Get("run")
+	listener := v2.NewChannelListener(256)
+	unsubscribe := h.bus.Subscribe(listener)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": subscribed\n\n")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e := <-listener.Events():
+			if run != "" && e.RunID != run {
+				continue
+			}
+			data, err := json.Marshal(newAdminEvent(e))
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+			flusher.Flush()
+		}
+	}
+}
+
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return false
+	}
+	return true
+}
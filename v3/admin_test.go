@@ -0,0 +1,182 @@
+package v3
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	v2 "func_decorator/v2"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func newAdminTestServer(t *testing.T) *httptest.Server {
+	functions := newTransportTestRegistry()
+
+	graph := v2.NewFunctionRegistry()
+	double, _ := v2.NewAnyFunction(v2.GetGenericType[int](), v2.GetGenericType[int](), func(ctx context.Context, req any) (any, error) {
+		return req.(int) * 2, nil
+	})
+	itoa, _ := v2.NewAnyFunction(v2.GetGenericType[int](), v2.GetGenericType[string](), func(ctx context.Context, req any) (any, error) {
+		return strconv.Itoa(req.(int)), nil
+	})
+	graph.RegisterFunction("double", double)
+	graph.RegisterFunction("itoa", itoa)
+	graph.ConnectFunctionNode("double", "itoa")
+
+	server := httptest.NewServer(NewAdminHandler(functions, graph))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func getJSON(t *testing.T, url string, out any) {
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s error = %v", url, err)
+	}
+	defer res.Body.Close()
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		t.Fatalf("GET %s decode error = %v", url, err)
+	}
+}
+
+func postRun(t *testing.T, url, body string) (int, AdminRunResult) {
+	res, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST %s error = %v", url, err)
+	}
+	defer res.Body.Close()
+	var result AdminRunResult
+	json.NewDecoder(res.Body).Decode(&result)
+	return res.StatusCode, result
+}
+
+func TestAdminHandlerInspect(t *testing.T) {
+	server := newAdminTestServer(t)
+
+	var functions []AdminFunction
+	getJSON(t, server.URL+"/functions", &functions)
+	names := make([]string, 0)
+	for _, f := range functions {
+		names = append(names, f.Registry+":"+v2.VersionedName(f.Name, f.Version)+" "+f.InputType+" -> "+f.OutputType)
+	}
+	want := []string{"v3:add@1.0.0 v3.transportInput -> v3.transportOutput", "v3:explode  -> ", "v3:fail  -> ", "v2:double int -> int", "v2:itoa int -> string"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("/functions = %v, want %v", names, want)
+	}
+
+	var graph v2.Graph
+	getJSON(t, server.URL+"/graph", &graph)
+	if len(graph.Nodes) != 2 || !reflect.DeepEqual(graph.Edges, []v2.GraphEdge{{From: "double", To: "itoa"}}) {
+		t.Errorf("/graph = %+v", graph)
+	}
+}
+
+// 조회 중에 v2 registry 에 노드를 등록, 연결해도 안전한지 검증 (go test -race)
+func TestAdminHandlerConcurrentRegister(t *testing.T) {
+	graph := v2.NewFunctionRegistry()
+	server := httptest.NewServer(NewAdminHandler(nil, graph))
+	t.Cleanup(server.Close)
+	inc, _ := v2.NewAnyFunction(v2.GetGenericType[int](), v2.GetGenericType[int](), func(ctx context.Context, req any) (any, error) {
+		return req.(int) + 1, nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			id := "inc" + strconv.Itoa(i)
+			if err := graph.RegisterFunction(id, inc); err != nil {
+				t.Error(err)
+				return
+			}
+			if i > 0 {
+				if err := graph.ConnectFunctionNode("inc"+strconv.Itoa(i-1), id); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}
+	}()
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		var g v2.Graph
+		getJSON(t, server.URL+"/graph", &g)
+		var functions []AdminFunction
+		getJSON(t, server.URL+"/functions", &functions)
+	}
+
+	var g v2.Graph
+	getJSON(t, server.URL+"/graph", &g)
+	if len(g.Nodes) != 50 || len(g.Edges) != 49 {
+		t.Errorf("/graph has %d nodes and %d edges, want 50 and 49", len(g.Nodes), len(g.Edges))
+	}
+}
+
+func TestAdminHandlerRun(t *testing.T) {
+	server := newAdminTestServer(t)
+
+	status, result := postRun(t, server.URL+"/run/v2/double", "21")
+	if status != http.StatusOK || len(result.Results) != 2 || result.Results[1].Output != "42" {
+		t.Errorf("/run/v2/double = %d %+v", status, result)
+	}
+	if status, result := postRun(t, server.URL+"/run/v3/missing", "{}"); status != http.StatusNotFound || result.Error == "" {
+		t.Errorf("/run/v3/missing = %d %+v", status, result)
+	}
+	if status, _ := postRun(t, server.URL+"/run/v2/double", `"text"`); status != http.StatusBadRequest {
+		t.Errorf("/run/v2/double with invalid input = %d", status)
+	}
+}
+
+func TestAdminHandlerEvents(t *testing.T) {
+	server := newAdminTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events", nil)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /events error = %v", err)
+	}
+	defer res.Body.Close()
+	reader := bufio.NewReader(res.Body)
+	if line, _ := reader.ReadString('\n'); line != ": subscribed\n" {
+		t.Fatalf("first line = %q", line)
+	}
+
+	status, result := postRun(t, server.URL+"/run/v3/add", `{"a":1,"b":2}`)
+	if status != http.StatusOK || string(result.Output) != `{"sum":3}` {
+		t.Fatalf("/run/v3/add = %d %+v", status, result)
+	}
+
+	types := make([]v2.EventType, 0)
+	for len(types) == 0 || types[len(types)-1] != v2.RunFinished {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read event error = %v", err)
+		}
+		data, ok := strings.CutPrefix(strings.TrimSpace(line), "data: ")
+		if !ok {
+			continue
+		}
+		var event AdminEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			t.Fatal(err)
+		}
+		if event.RunID != result.RunID {
+			t.Errorf("event run = %s, want %s", event.RunID, result.RunID)
+		}
+		types = append(types, event.Type)
+	}
+	want := []v2.EventType{v2.RunStarted, v2.NodeStarted, v2.NodeFinished, v2.RunFinished}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("events = %v, want %v", types, want)
+	}
+}
@@ -3,6 +3,7 @@ package example_func
 import (
 	"context"
 	"errors"
+	v3 "func_decorator/v3"
 )
 
 type AddIntInput struct {
@@ -14,11 +15,11 @@ type AddIntOutput struct {
 
 // AddInt
 // input : AddIntInput
-// output : AddIntOutput, AddIntInput
+// output : v3.Tuple (output: AddIntOutput, input: AddIntInput)
 func AddInt(ctx context.Context, input any) (any, error) {
 	if aii, ok := input.(AddIntInput); ok {
 		r := aii.Num1 + aii.Num2
-		return []any{AddIntOutput{Result: r}, aii}, nil
+		return v3.NewTuple(v3.Field("output", AddIntOutput{Result: r}), v3.Field("input", aii)), nil
 	}
 	return nil, errors.New("AddInt - invalid input")
 }
@@ -32,11 +33,11 @@ type MultiplyIntOutput struct {
 
 // MultiplyInt
 // input : MultiplyIntInput
-// output : MultiplyIntOutput, MultiplyIntInput
+// output : v3.Tuple (output: MultiplyIntOutput, input: MultiplyIntInput)
 func MultiplyInt(ctx context.Context, input any) (any, error) {
 	if mii, ok := input.(MultiplyIntInput); ok {
 		r := mii.Num1 * mii.Num2
-		return []any{MultiplyIntOutput{Result: r}, mii}, nil
+		return v3.NewTuple(v3.Field("output", MultiplyIntOutput{Result: r}), v3.Field("input", mii)), nil
 	}
 	return nil, errors.New("MultiplyInt - invalid input")
 }
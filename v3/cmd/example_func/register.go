@@ -0,0 +1,52 @@
+package example_func
+
+import (
+	"context"
+	v2 "func_decorator/v2"
+	v3 "func_decorator/v3"
+)
+
+// Register | 예제 function 과 converter 를 metadata 와 함께 registry 에 등록합니다.
+// add -> add-to-multiply -> multiply 순으로 연결하면 (Num1 + Num2) * Num1 을 계산합니다.
+func Register(registry v3.FunctionRegistry) error {
+	functions := []struct {
+		metadata v3.FunctionMetadata
+		fn       v3.FunctionType
+	}{
+		{v3.FunctionMetadata{
+			Name:        "add",
+			Description: "Num1 + Num2",
+			InputType:   v2.GetGenericType[AddIntInput](),
+			OutputType:  v2.GetGenericType[v3.Tuple](),
+			Version:     "1.0.0",
+			Tags:        []string{"math"},
+			Idempotent:  true,
+		}, AddInt},
+		{v3.FunctionMetadata{
+			Name:        "multiply",
+			Description: "Num1 * Num2",
+			InputType:   v2.GetGenericType[MultiplyIntInput](),
+			OutputType:  v2.GetGenericType[v3.Tuple](),
+			Version:     "1.0.0",
+			Tags:        []string{"math"},
+			Idempotent:  true,
+		}, MultiplyInt},
+		{v3.FunctionMetadata{
+			Name:        "add-to-multiply",
+			Description: "add 의 결과와 Num1 으로 multiply 의 input 을 만듭니다.",
+			InputType:   v2.GetGenericType[v3.Tuple](),
+			OutputType:  v2.GetGenericType[MultiplyIntInput](),
+			Tags:        []string{"converter"},
+			Idempotent:  true,
+		}, v3.TupleConverter2("output", "input",
+			func(ctx context.Context, aio AddIntOutput, aii AddIntInput) (any, error) {
+				return MultiplyIntInput{Num1: aii.Num1, Num2: aio.Result}, nil
+			})},
+	}
+	for _, f := range functions {
+		if err := registry.RegisterFunctionWithMetadata(f.metadata, f.fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+// fdctl | registry 의 function 을 조회하고 pipeline 정의 파일을 검사, 실행하는 CLI
+//
+//	fdctl [flags] list [-tag tag] [-json]
+//	fdctl [flags] describe [-json] <name>
+//	fdctl [flags] run [-timeout 30s] <definition.json>   (input JSON 은 stdin 으로)
+//	fdctl [flags] validate <definition.json>
+//	fdctl [flags] graph <definition.json>                (Graphviz DOT 출력)
+//
+// function 은 -examples, -plugin, -go-plugin, -remote 로 registry 에 등록합니다.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	v3 "func_decorator/v3"
+	"func_decorator/v3/cmd/example_func"
+	"io"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// stringsFlag | 여러 번 지정할 수 있는 flag
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var plugins, goPlugins, remotes stringsFlag
+	fs := flag.NewFlagSet("fdctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	examples := fs.Bool("examples", false, "register the example functions (add, multiply, add-to-multiply)")
+	fs.Var(&plugins, "plugin", "command of a subprocess plugin to load (repeatable)")
+	fs.Var(&goPlugins, "go-plugin", "path of a Go plugin (.so) to load (repeatable)")
+	fs.Var(&remotes, "remote", "base URL of a remote function server to register (repeatable)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: fdctl [flags] <list|describe|run|validate|graph> [args]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	registry := v3.NewSimpleFunctionRegistry()
+	unload, err := loadFunctions(registry, stderr, *examples, plugins, goPlugins, remotes)
+	defer unload()
+	if err != nil {
+		fmt.Fprintln(stderr, "fdctl:", err)
+		return exitError
+	}
+
+	command, commandArgs := fs.Arg(0), fs.Args()[1:]
+	var commandFunc func(registry v3.FunctionRegistry, args []string, stdin io.Reader, stdout, stderr io.Writer) error
+	switch command {
+	case "list":
+		commandFunc = listCommand
+	case "describe":
+		commandFunc = describeCommand
+	case "run":
+		commandFunc = runCommand
+	case "validate":
+		commandFunc = validateCommand
+	case "graph":
+		commandFunc = graphCommand
+	default:
+		fmt.Fprintf(stderr, "fdctl: unknown command '%s'\n", command)
+		fs.Usage()
+		return exitUsage
+	}
+	if err := commandFunc(registry, commandArgs, stdin, stdout, stderr); err != nil {
+		if errors.Is(err, errUsage) {
+			return exitUsage
+		}
+		fmt.Fprintf(stderr, "fdctl %s: %v\n", command, err)
+		return exitError
+	}
+	return exitOK
+}
+
+// loadFunctions | flag 로 지정한 function 을 registry 에 등록하고, plugin 을 내릴 func 을 반환합니다.
+// subprocess plugin 의 stderr 는 stderr 로 연결됩니다.
+func loadFunctions(registry v3.FunctionRegistry, stderr io.Writer, examples bool, plugins, goPlugins, remotes []string) (func(), error) {
+	var registrations []*v3.PluginRegistration
+	unload := func() {
+		for _, r := range registrations {
+			_ = r.Unload()
+		}
+	}
+	register := func(plugin v3.Plugin, err error) error {
+		if err != nil {
+			return err
+		}
+		r, err := v3.RegisterPlugin(registry, plugin)
+		if err != nil {
+			_ = plugin.Close()
+			return err
+		}
+		registrations = append(registrations, r)
+		return nil
+	}
+
+	if examples {
+		if err := example_func.Register(registry); err != nil {
+			return unload, err
+		}
+	}
+	for _, command := range plugins {
+		fields := strings.Fields(command)
+		if len(fields) == 0 {
+			return unload, errors.New("empty plugin command")
+		}
+		cmd := exec.Command(fields[0], fields[1:]...)
+		cmd.Stderr = stderr
+		if err := register(v3.StartProcessPlugin(fields[0], cmd)); err != nil {
+			return unload, err
+		}
+	}
+	for _, path := range goPlugins {
+		if err := register(v3.OpenGoPlugin(path)); err != nil {
+			return unload, err
+		}
+	}
+	for _, url := range remotes {
+		if err := v3.NewRemoteClient(url, nil).RegisterRemote(context.Background(), registry); err != nil {
+			return unload, err
+		}
+	}
+	return unload, nil
+}
+
+// errUsage | 명령의 flag, 인자가 잘못되었을 때의 에러, 에러와 usage 는 이미 출력됨
+var errUsage = errors.New("usage")
+
+// parseCommand | 명령의 flag 를 읽고, 필요한 위치 인자 수가 맞는지 검사합니다. usage 와 flag 에러는 stderr 로 출력합니다.
+func parseCommand(fs *flag.FlagSet, stderr io.Writer, args []string, usage string, nargs int) error {
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: fdctl %s %s\n", fs.Name(), usage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return errUsage // flag 패키지가 에러와 usage 를 출력함, -h 도 여기에 해당
+	}
+	if fs.NArg() != nargs {
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+func listCommand(registry v3.FunctionRegistry, args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	tag := fs.String("tag", "", "list only the functions with the tag")
+	asJSON := fs.Bool("json", false, "print as JSON")
+	if err := parseCommand(fs, stderr, args, "[-tag tag] [-json]", 0); err != nil {
+		return err
+	}
+
+	list := registry.List()
+	if *tag != "" {
+		list = registry.FindByTag(*tag)
+	}
+	if *asJSON {
+		infos := make([]v3.FunctionInfo, 0, len(list))
+		for _, metadata := range list {
+			infos = append(infos, metadata.Info())
+		}
+		return printJSON(stdout, infos)
+	}
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tVERSION\tINPUT\tOUTPUT\tTAGS\tDESCRIPTION")
+	for _, metadata := range list {
+		info := metadata.Info()
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", info.Name, info.Version, info.InputType, info.OutputType, strings.Join(info.Tags, ","), info.Description)
+	}
+	return w.Flush()
+}
+
+func describeCommand(registry v3.FunctionRegistry, args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("describe", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print as JSON")
+	if err := parseCommand(fs, stderr, args, "[-json] <name>", 1); err != nil {
+		return err
+	}
+
+	metadata, err := registry.Describe(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	info := metadata.Info()
+	if *asJSON {
+		return printJSON(stdout, info)
+	}
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "Name:\t%s\n", info.Name)
+	fmt.Fprintf(w, "Version:\t%s\n", info.Version)
+	fmt.Fprintf(w, "Description:\t%s\n", info.Description)
+	fmt.Fprintf(w, "Input:\t%s\n", info.InputType)
+	fmt.Fprintf(w, "Output:\t%s\n", info.OutputType)
+	fmt.Fprintf(w, "Tags:\t%s\n", strings.Join(info.Tags, ","))
+	fmt.Fprintf(w, "Owner:\t%s\n", info.Owner)
+	fmt.Fprintf(w, "Idempotent:\t%t\n", info.Idempotent)
+	if versions := registry.Versions(info.Name); len(versions) > 1 {
+		fmt.Fprintf(w, "Versions:\t%s\n", strings.Join(versions, ", "))
+	}
+	return w.Flush()
+}
+
+func runCommand(registry v3.FunctionRegistry, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	timeout := fs.Duration("timeout", 0, "cancel the run after the duration (0 means no timeout)")
+	if err := parseCommand(fs, stderr, args, "[-timeout 30s] <definition.json>", 1); err != nil {
+		return err
+	}
+
+	def, err := readDefinition(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	pipeline, err := def.Build(registry)
+	if err != nil {
+		return err
+	}
+	input, err := readInput(stdin, def.InputType(registry))
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	begin := time.Now()
+	res, err := pipeline.Run(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed after %s: %w", time.Since(begin), err)
+	}
+	return printJSON(stdout, res)
+}
+
+func validateCommand(registry v3.FunctionRegistry, args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	if err := parseCommand(fs, stderr, args, "<definition.json>", 1); err != nil {
+		return err
+	}
+
+	def, err := readDefinition(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if err := def.Validate(registry); err != nil {
+		return fmt.Errorf("%s is invalid:\n%w", fs.Arg(0), err)
+	}
+	fmt.Fprintf(stdout, "%s is valid\n", fs.Arg(0))
+	return nil
+}
+
+func graphCommand(registry v3.FunctionRegistry, args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("graph", flag.ContinueOnError)
+	if err := parseCommand(fs, stderr, args, "<definition.json>", 1); err != nil {
+		return err
+	}
+
+	def, err := readDefinition(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	pipeline, err := def.Build(registry)
+	if err != nil {
+		return err
+	}
+	name := def.Name
+	if name == "" {
+		name = "pipeline"
+	}
+	_, err = io.WriteString(stdout, v3.PlanDOT(name, pipeline.Plan()))
+	return err
+}
+
+func readDefinition(path string) (*v3.PipelineDefinition, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return v3.ParseDefinition(f)
+}
+
+// readInput | stdin 의 JSON 을 첫 step 의 InputType 으로 decode 합니다. 타입을 모르면 any 로 decode 합니다.
+func readInput(stdin io.Reader, typ reflect.Type) (any, error) {
+	if typ == nil {
+		typ = reflect.TypeOf((*any)(nil)).Elem()
+	}
+	value := reflect.New(typ)
+	if err := json.NewDecoder(stdin).Decode(value.Interface()); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid input for %s: %w", typ, err)
+	}
+	return value.Elem().Interface(), nil
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		code       int
+		stdout     []string // stdout 에 포함되어야 하는 문자열
+		stderr     []string // stderr 에 포함되어야 하는 문자열
+		noStderr   []string // stderr 에 없어야 하는 문자열
+		wantOutput string   // 비어 있지 않으면 stdout 전체와 비교
+	}{
+		{
+			name:   "list",
+			args:   []string{"-examples", "list"},
+			code:   exitOK,
+			stdout: []string{"NAME", "add ", "add-to-multiply", "multiply "},
+		},
+		{
+			name:   "list by tag",
+			args:   []string{"-examples", "list", "-tag", "converter", "-json"},
+			code:   exitOK,
+			stdout: []string{`"name": "add-to-multiply"`},
+		},
+		{
+			name:   "describe",
+			args:   []string{"-examples", "describe", "add@^1"},
+			code:   exitOK,
+			stdout: []string{"Name:", "add", "Version:      1.0.0", "example_func.AddIntInput"},
+		},
+		{
+			name:   "describe missing",
+			args:   []string{"-examples", "describe", "missing"},
+			code:   exitError,
+			stderr: []string{"missing"},
+		},
+		{
+			name:  "run",
+			args:  []string{"-examples", "run", "examples/calc.json"},
+			stdin: `{"Num1":10,"Num2":20}`,
+			code:  exitOK,
+			wantOutput: compactJSON(`[
+				{"output": {"Result": 300}, "input": {"Num1": 10, "Num2": 30}},
+				{"output": {"Result": 30}, "input": {"Num1": 10, "Num2": 20}}
+			]`),
+		},
+		{
+			name:   "validate",
+			args:   []string{"-examples", "validate", "examples/calc.json"},
+			code:   exitOK,
+			stdout: []string{"examples/calc.json is valid"},
+		},
+		{
+			name:   "validate invalid",
+			args:   []string{"-examples", "validate", "testdata/invalid.json"},
+			code:   exitError,
+			stderr: []string{"testdata/invalid.json is invalid", "type mismatch: 'add' returns v3.Tuple but 'multiply' requires example_func.MultiplyIntInput"},
+		},
+		{
+			name:   "graph",
+			args:   []string{"-examples", "graph", "examples/calc.json"},
+			code:   exitOK,
+			stdout: []string{`digraph "calc" {`, "shape=ellipse", "->"},
+		},
+		{
+			name:   "unknown command",
+			args:   []string{"-examples", "deploy"},
+			code:   exitUsage,
+			stderr: []string{"unknown command 'deploy'", "usage: fdctl"},
+		},
+		{
+			name:   "missing argument",
+			args:   []string{"-examples", "run"},
+			code:   exitUsage,
+			stderr: []string{"usage: fdctl run"},
+		},
+		{
+			name:     "unknown flag",
+			args:     []string{"-examples", "list", "-bogus"},
+			code:     exitUsage,
+			stderr:   []string{"flag provided but not defined: -bogus", "usage: fdctl list"},
+			noStderr: []string{"fdctl list:"},
+		},
+		{
+			name: "no command",
+			args: []string{},
+			code: exitUsage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.code {
+				t.Fatalf("run() = %d, want %d\nstdout: %s\nstderr: %s", code, tt.code, stdout.String(), stderr.String())
+			}
+			for _, want := range tt.stdout {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("stdout = %q, want to contain %q", stdout.String(), want)
+				}
+			}
+			for _, want := range tt.stderr {
+				if !strings.Contains(stderr.String(), want) {
+					t.Errorf("stderr = %q, want to contain %q", stderr.String(), want)
+				}
+			}
+			for _, unwanted := range tt.noStderr {
+				if strings.Contains(stderr.String(), unwanted) {
+					t.Errorf("stderr = %q, should not contain %q", stderr.String(), unwanted)
+				}
+			}
+			if tt.wantOutput != "" && compactJSON(stdout.String()) != tt.wantOutput {
+				t.Errorf("stdout = %s, want %s", compactJSON(stdout.String()), tt.wantOutput)
+			}
+		})
+	}
+}
+
+// compactJSON | 공백을 지워 JSON 출력을 비교할 수 있게 합니다.
+func compactJSON(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	v3 "func_decorator/v3"
 	"func_decorator/v3/cmd/example_func"
@@ -11,36 +10,28 @@ import (
 func main() {
 	// FunctionRegistry 및 함수 등록
 	registry := v3.NewSimpleFunctionRegistry()
-	registry.RegisterFunction("add", example_func.AddInt)
-	registry.RegisterFunction("multiply", example_func.MultiplyInt)
+	if err := example_func.Register(registry); err != nil {
+		fmt.Printf("Error: %+v \n", err)
+		return
+	}
+	for _, metadata := range registry.FindByTag("math") {
+		fmt.Println("Function: ", metadata)
+	}
 
 	// TaskBuilder 로 func 조립
 	addFn, _ := registry.GetFunction("add")
 	multiplyFn, _ := registry.GetFunction("multiply")
+	addToMultiply, _ := registry.GetFunction("add-to-multiply")
 
 	tb := v3.NewTaskBuilder(v3.Composite)
-	task := tb.AddFunction(addFn).
-		AttachConverter(
-			func(ctx context.Context, a any) (any, error) {
-				errMsg := "converter is invalid input for AddInt output, MultiplyInt input"
-				inputs, ok := a.([]any)
-				if !ok {
-					return nil, errors.New(errMsg)
-				}
-				aio, ok := inputs[0].(example_func.AddIntOutput)
-				if !ok {
-					return nil, errors.New(errMsg)
-				}
-				aii, ok := inputs[1].(example_func.AddIntInput)
-				if !ok {
-					return nil, errors.New(errMsg)
-				}
-
-				return example_func.MultiplyIntInput{Num1: aii.Num1, Num2: aio.Result}, nil
-
-			}).
+	task, err := tb.AddFunction(addFn).
+		AttachConverter(addToMultiply).
 		AddLastFunction(multiplyFn).
 		Build()
+	if err != nil {
+		fmt.Printf("Error: %+v \n", err)
+		return
+	}
 
 	// Task 실행
 	ctx := context.Background()
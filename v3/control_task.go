@@ -0,0 +1,253 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	v2 "func_decorator/v2"
+	"reflect"
+)
+
+// Predicate | ConditionalTask 에서 branch 를 고르기 위한 조건 func
+type Predicate func(ctx context.Context, input any) (bool, error)
+
+// DiscriminatorFunc | SwitchTask 에서 실행할 case 의 key 를 고르는 func
+type DiscriminatorFunc func(ctx context.Context, input any) (any, error)
+
+// SequentialTask | Tasks 를 순서대로 실행하며, 앞 Task 의 결과를 다음 Task 의 입력으로 넘깁니다.
+type SequentialTask struct {
+	Tasks []Task
+}
+
+func NewSequentialTask(tasks ...Task) *SequentialTask {
+	return &SequentialTask{Tasks: tasks}
+}
+
+// AddFunction | 마지막 Task 가 CompositeTask 라면 그 뒤에, 아니라면 새 CompositeTask 를 만들어 추가합니다.
+func (t *SequentialTask) AddFunction(fn FunctionType) {
+	t.lastComposite().AddFunction(fn)
+}
+
+func (t *SequentialTask) lastComposite() *CompositeTask {
+	if len(t.Tasks) > 0 {
+		if last, ok := t.Tasks[len(t.Tasks)-1].(*CompositeTask); ok {
+			return last
+		}
+	}
+	last := NewCompositeTask()
+	t.Tasks = append(t.Tasks, last)
+	return last
+}
+
+func (t *SequentialTask) Execute(ctx context.Context, input any) (any, error) {
+	current := input
+	for i, task := range t.Tasks {
+		var err error
+		if current, err = task.Execute(ctx, current); err != nil {
+			return nil, fmt.Errorf("sequential task[%d]: %w", i, err)
+		}
+	}
+	return current, nil
+}
+
+func (t *SequentialTask) Plan() *v2.PlanNode {
+	plan := newTaskPlan("sequential")
+	for i, task := range t.Tasks {
+		plan.Children = append(plan.Children, namedPlan(fmt.Sprintf("task[%d]", i), task))
+	}
+	return plan
+}
+
+type conditionalBranch struct {
+	predicate Predicate
+	task      Task
+}
+
+// ConditionalTask | Branches 의 predicate 를 순서대로 검사하여 처음 true 인 branch 의 Task 를 실행합니다.
+// 모든 predicate 가 false 라면 Otherwise 를 실행하며, Otherwise 도 없다면 입력을 그대로 반환합니다.
+type ConditionalTask struct {
+	branches  []conditionalBranch
+	Otherwise Task
+}
+
+func NewConditionalTask() *ConditionalTask {
+	return &ConditionalTask{}
+}
+
+// When | predicate 가 true 일 때 실행할 branch 를 추가합니다.
+func (t *ConditionalTask) When(predicate Predicate, task Task) *ConditionalTask {
+	t.branches = append(t.branches, conditionalBranch{predicate: predicate, task: task})
+	return t
+}
+
+// AddFunction | Otherwise branch 에 function 을 추가합니다.
+func (t *ConditionalTask) AddFunction(fn FunctionType) {
+	t.otherwise().AddFunction(fn)
+}
+
+func (t *ConditionalTask) otherwise() Task {
+	if t.Otherwise == nil {
+		t.Otherwise = NewCompositeTask()
+	}
+	return t.Otherwise
+}
+
+func (t *ConditionalTask) Execute(ctx context.Context, input any) (any, error) {
+	for i, branch := range t.branches {
+		ok, err := branch.predicate(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("conditional when[%d] predicate: %w", i, err)
+		}
+		if ok {
+			return branch.task.Execute(ctx, input)
+		}
+	}
+	if t.Otherwise != nil {
+		return t.Otherwise.Execute(ctx, input)
+	}
+	return input, nil
+}
+
+func (t *ConditionalTask) Plan() *v2.PlanNode {
+	plan := newTaskPlan("conditional")
+	for i, branch := range t.branches {
+		plan.Children = append(plan.Children, namedPlan(fmt.Sprintf("when[%d]", i), branch.task))
+	}
+	if t.Otherwise != nil {
+		plan.Children = append(plan.Children, namedPlan("otherwise", t.Otherwise))
+	}
+	return plan
+}
+
+// LoopTask | slice(array) 입력의 각 원소에 Body 를 순서대로 적용하고, 결과를 []any 로 반환합니다.
+type LoopTask struct {
+	Body Task
+}
+
+func NewLoopTask(body Task) *LoopTask {
+	return &LoopTask{Body: body}
+}
+
+// AddFunction | Body 에 function 을 추가합니다.
+func (t *LoopTask) AddFunction(fn FunctionType) {
+	t.body().AddFunction(fn)
+}
+
+func (t *LoopTask) body() Task {
+	if t.Body == nil {
+		t.Body = NewCompositeTask()
+	}
+	return t.Body
+}
+
+func (t *LoopTask) Execute(ctx context.Context, input any) (any, error) {
+	v := reflect.ValueOf(input)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("loop task requires slice input, got %T", input)
+	}
+
+	results := make([]any, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		res, err := t.body().Execute(ctx, v.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("loop element[%d]: %w", i, err)
+		}
+		results[i] = res
+	}
+	return results, nil
+}
+
+func (t *LoopTask) Plan() *v2.PlanNode {
+	plan := newTaskPlan("loop")
+	plan.Children = append(plan.Children, namedPlan("each", t.body()))
+	return plan
+}
+
+// SwitchTask | Discriminator 가 반환한 key 의 case Task 를 실행합니다.
+// 일치하는 case 가 없으면 Default 를 실행하며, Default 도 없다면 에러를 반환합니다.
+type SwitchTask struct {
+	Discriminator DiscriminatorFunc
+	cases         map[any]Task
+	keys          []any // case 가 추가된 순서
+	Default       Task
+	err           error // Case 로 추가하지 못한 key 의 에러, Execute 에서 반환
+}
+
+func NewSwitchTask(discriminator DiscriminatorFunc) *SwitchTask {
+	return &SwitchTask{
+		Discriminator: discriminator,
+		cases:         make(map[any]Task),
+	}
+}
+
+// Case | key 에 해당하는 Task 를 추가합니다. key 는 비교 가능한(comparable) 값이어야 합니다.
+// slice, map, func 처럼 비교할 수 없는 key 는 추가되지 않으며, Execute 가 에러를 반환합니다.
+func (t *SwitchTask) Case(key any, task Task) *SwitchTask {
+	if err := checkSwitchKey(key); err != nil {
+		t.err = errors.Join(t.err, err)
+		return t
+	}
+	if _, exists := t.cases[key]; !exists {
+		t.keys = append(t.keys, key)
+	}
+	t.cases[key] = task
+	return t
+}
+
+// AddFunction | Default case 에 function 을 추가합니다.
+func (t *SwitchTask) AddFunction(fn FunctionType) {
+	t.defaultTask().AddFunction(fn)
+}
+
+func (t *SwitchTask) defaultTask() Task {
+	if t.Default == nil {
+		t.Default = NewCompositeTask()
+	}
+	return t.Default
+}
+
+func (t *SwitchTask) Execute(ctx context.Context, input any) (any, error) {
+	if t.Discriminator == nil {
+		return nil, fmt.Errorf("switch task has no discriminator")
+	}
+	if t.err != nil {
+		return nil, t.err
+	}
+	key, err := t.Discriminator(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("switch discriminator: %w", err)
+	}
+	if err := checkSwitchKey(key); err != nil {
+		return nil, fmt.Errorf("switch discriminator: %w", err)
+	}
+	if task, ok := t.cases[key]; ok {
+		return task.Execute(ctx, input)
+	}
+	if t.Default != nil {
+		return t.Default.Execute(ctx, input)
+	}
+	return nil, fmt.Errorf("switch task has no case for key '%v'", key)
+}
+
+// checkSwitchKey | key 를 map 의 key 로 쓸 수 있는지 검사합니다.
+// 타입이 comparable 이어도 interface 필드에 slice 등이 담겨 있으면 비교할 수 없으므로 값으로 검사합니다.
+func checkSwitchKey(key any) error {
+	if key != nil && !reflect.ValueOf(key).Comparable() {
+		return fmt.Errorf("switch key must be comparable, got %T", key)
+	}
+	return nil
+}
+
+func (t *SwitchTask) Plan() *v2.PlanNode {
+	plan := newTaskPlan("switch")
+	for _, key := range t.keys {
+		plan.Children = append(plan.Children, namedPlan(fmt.Sprintf("case[%v]", key), t.cases[key]))
+	}
+	if t.Default != nil {
+		plan.Children = append(plan.Children, namedPlan("default", t.Default))
+	}
+	return plan
+}
@@ -0,0 +1,126 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func addTask(n int) Task {
+	return newFuncTask(func(ctx context.Context, a any) (any, error) { return a.(int) + n, nil })
+}
+
+func TestSequentialTask(t *testing.T) {
+	task := mustBuild(t, NewTaskBuilder(Sequential).
+		AddTask(addTask(1)).
+		AddTask(addTask(10)).
+		AddLastFunction(func(ctx context.Context, a any) (any, error) { return a.(int) * 2, nil }))
+
+	res, err := task.Execute(context.Background(), 1)
+	if err != nil || res != 24 {
+		t.Errorf("Execute() = (%v, %v), want 24", res, err)
+	}
+}
+
+func TestConditionalTask(t *testing.T) {
+	isNegative := func(ctx context.Context, a any) (bool, error) { return a.(int) < 0, nil }
+	isZero := func(ctx context.Context, a any) (bool, error) { return a.(int) == 0, nil }
+	task := mustBuild(t, NewTaskBuilder(Conditional).
+		When(isNegative, newFuncTask(func(ctx context.Context, a any) (any, error) { return "negative", nil })).
+		When(isZero, newFuncTask(func(ctx context.Context, a any) (any, error) { return "zero", nil })).
+		Otherwise(newFuncTask(func(ctx context.Context, a any) (any, error) { return "positive", nil })))
+
+	for input, want := range map[int]string{-1: "negative", 0: "zero", 1: "positive"} {
+		if res, err := task.Execute(context.Background(), input); err != nil || res != want {
+			t.Errorf("Execute(%d) = (%v, %v), want %s", input, res, err, want)
+		}
+	}
+}
+
+func TestConditionalTaskOtherwiseWithFunction(t *testing.T) {
+	otherwise := addTask(1)
+	task := mustBuild(t, NewTaskBuilder(Conditional).
+		When(func(ctx context.Context, a any) (bool, error) { return a.(int) < 0, nil }, addTask(-1)).
+		Otherwise(otherwise).
+		AddLastFunction(func(ctx context.Context, a any) (any, error) { return a.(int) * 2, nil }))
+
+	// function 은 Otherwise 뒤에 실행되며, Otherwise 로 넘긴 Task 는 그대로 남아 있음
+	if res, err := task.Execute(context.Background(), 1); err != nil || res != 4 {
+		t.Errorf("Execute() = (%v, %v), want 4", res, err)
+	}
+	if steps := otherwise.(*CompositeTask).Steps; len(steps) != 1 {
+		t.Errorf("otherwise task should not be modified, got %d steps", len(steps))
+	}
+}
+
+func TestLoopTask(t *testing.T) {
+	task := mustBuild(t, NewTaskBuilder(Loop).AddTask(addTask(1)))
+
+	res, err := task.Execute(context.Background(), []int{1, 2, 3})
+	if err != nil || !reflect.DeepEqual(res, []any{2, 3, 4}) {
+		t.Errorf("Execute() = (%v, %v), want [2 3 4]", res, err)
+	}
+	if _, err := task.Execute(context.Background(), 1); err == nil {
+		t.Errorf("Execute() should fail with non-slice input")
+	}
+	failOnTwo := NewLoopTask(newFuncTask(func(ctx context.Context, a any) (any, error) {
+		if a == 2 {
+			return nil, errors.New("fail")
+		}
+		return a, nil
+	}))
+	if _, err := failOnTwo.Execute(context.Background(), []int{1, 2}); err == nil || !strings.Contains(err.Error(), "element[1]") {
+		t.Errorf("Execute() error = %v, want element[1] error", err)
+	}
+}
+
+func TestSwitchTask(t *testing.T) {
+	kind := func(ctx context.Context, a any) (any, error) { return reflect.TypeOf(a).Kind(), nil }
+	task := mustBuild(t, NewTaskBuilder(Switch).
+		Discriminator(kind).
+		Case(reflect.Int, addTask(1)).
+		Case(reflect.String, newFuncTask(func(ctx context.Context, a any) (any, error) { return a.(string) + "!", nil })))
+
+	if res, err := task.Execute(context.Background(), 1); err != nil || res != 2 {
+		t.Errorf("Execute(1) = (%v, %v), want 2", res, err)
+	}
+	if res, err := task.Execute(context.Background(), "a"); err != nil || res != "a!" {
+		t.Errorf("Execute(a) = (%v, %v), want a!", res, err)
+	}
+	if _, err := task.Execute(context.Background(), 1.0); err == nil {
+		t.Errorf("Execute() should fail when no case matches")
+	}
+
+	want := strings.Join([]string{
+		"task switch",
+		"  task case[int] composite",
+		"    function step[0]",
+		"  task case[string] composite",
+		"    function step[0]",
+		"",
+	}, "\n")
+	if plan := PlanOf(task).String(); plan != want {
+		t.Errorf("PlanOf() =\n%s\nwant\n%s", plan, want)
+	}
+}
+
+func TestSwitchTaskUncomparableKey(t *testing.T) {
+	identity := func(ctx context.Context, a any) (any, error) { return a, nil }
+	if _, err := NewTaskBuilder(Switch).Discriminator(identity).Case([]int{1}, addTask(1)).Build(); err == nil || !strings.Contains(err.Error(), "comparable") {
+		t.Errorf("Build() error = %v, want comparable error", err)
+	}
+
+	task := NewSwitchTask(identity).Case(map[string]int{}, addTask(1))
+	if _, err := task.Execute(context.Background(), 1); err == nil || !strings.Contains(err.Error(), "comparable") {
+		t.Errorf("Execute() error = %v, want comparable error of the case key", err)
+	}
+
+	task = NewSwitchTask(identity).Case(1, addTask(1))
+	for _, input := range []any{[]int{1}, struct{ V any }{V: []int{1}}} {
+		if _, err := task.Execute(context.Background(), input); err == nil || !strings.Contains(err.Error(), "comparable") {
+			t.Errorf("Execute(%v) error = %v, want comparable error of the discriminator result", input, err)
+		}
+	}
+}
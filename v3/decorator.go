@@ -0,0 +1,55 @@
+package v3
+
+import (
+	v2 "func_decorator/v2"
+)
+
+// DecoratingRegistry | 등록되는 모든 function 을 기본 v2.DecoratorSet 으로 감싸는 FunctionRegistry
+// RegisterFunctionWithDecorators 로 function 별로 다른 DecoratorSet 을 사용할 수 있습니다.
+type DecoratingRegistry struct {
+	FunctionRegistry
+	defaults v2.DecoratorSet
+}
+
+// NewDecoratingRegistry | registry 에 등록되는 function 을 defaults 로 감싸는 registry 를 만듭니다.
+func NewDecoratingRegistry(registry FunctionRegistry, defaults v2.DecoratorSet) *DecoratingRegistry {
+	return &DecoratingRegistry{FunctionRegistry: registry, defaults: defaults}
+}
+
+// Defaults | 기본 DecoratorSet 을 반환합니다. With, Without 으로 function 별 DecoratorSet 을 만들 때 사용합니다.
+func (r *DecoratingRegistry) Defaults() v2.DecoratorSet {
+	return r.defaults
+}
+
+func (r *DecoratingRegistry) RegisterFunction(name string, fn FunctionType) error {
+	return r.RegisterFunctionWithMetadata(FunctionMetadata{Name: name}, fn)
+}
+
+func (r *DecoratingRegistry) RegisterFunctionWithMetadata(metadata FunctionMetadata, fn FunctionType) error {
+	return r.RegisterFunctionWithDecorators(metadata, fn, r.defaults)
+}
+
+// RegisterFunctionWithDecorators | 기본 DecoratorSet 대신 decorators 로 감싸서 등록합니다.
+func (r *DecoratingRegistry) RegisterFunctionWithDecorators(metadata FunctionMetadata, fn FunctionType, decorators v2.DecoratorSet) error {
+	return r.FunctionRegistry.RegisterFunctionWithMetadata(metadata, decorate(metadata.Name, fn, decorators))
+}
+
+func (r *DecoratingRegistry) ReplaceFunction(name string, fn FunctionType) error {
+	return r.ReplaceFunctionWithMetadata(FunctionMetadata{Name: name}, fn)
+}
+
+func (r *DecoratingRegistry) ReplaceFunctionWithMetadata(metadata FunctionMetadata, fn FunctionType) error {
+	return r.ReplaceFunctionWithDecorators(metadata, fn, r.defaults)
+}
+
+// ReplaceFunctionWithDecorators | 기본 DecoratorSet 대신 decorators 로 감싸서 교체합니다.
+func (r *DecoratingRegistry) ReplaceFunctionWithDecorators(metadata FunctionMetadata, fn FunctionType, decorators v2.DecoratorSet) error {
+	return r.FunctionRegistry.ReplaceFunctionWithMetadata(metadata, decorate(metadata.Name, fn, decorators))
+}
+
+func decorate(name string, fn FunctionType, decorators v2.DecoratorSet) FunctionType {
+	if fn == nil || len(decorators) == 0 {
+		return fn // nil 은 등록 시 에러로 처리됨
+	}
+	return FunctionType(decorators.Apply(name, v2.CallFunc(fn)))
+}
@@ -0,0 +1,46 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	v2 "func_decorator/v2"
+	"testing"
+	"time"
+)
+
+func TestDecoratingRegistry(t *testing.T) {
+	metrics := v2.NewMetrics()
+	registry := NewDecoratingRegistry(NewSimpleFunctionRegistry(), v2.NewDecoratorSet(nil, metrics, 20*time.Millisecond))
+
+	slow := func(ctx context.Context, a any) (any, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+			return a, nil
+		}
+	}
+	registry.RegisterFunction("slow", slow)
+	registry.RegisterFunctionWithDecorators(FunctionMetadata{Name: "patient"}, slow, registry.Defaults().Without(v2.DecoratorTimeout))
+
+	fn, _ := registry.GetFunction("slow")
+	if _, err := fn(context.Background(), 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("slow error = %v, want DeadlineExceeded", err)
+	}
+	fn, _ = registry.GetFunction("patient")
+	if res, err := fn(context.Background(), 1); err != nil || res != 1 {
+		t.Errorf("patient = (%v, %v), want 1", res, err)
+	}
+	if m := metrics.Get("slow"); m.Calls != 1 || m.Errors != 1 {
+		t.Errorf("metrics(slow) = %+v", m)
+	}
+
+	// Task 에서 사용해도 감싼 function 이 호출됨
+	task := mustBuild(t, NewTaskBuilder(Composite).AddLastFunction(fn))
+	if _, err := task.Execute(context.Background(), 2); err != nil {
+		t.Fatal(err)
+	}
+	if m := metrics.Get("patient"); m.Calls != 2 {
+		t.Errorf("metrics(patient) = %+v", m)
+	}
+}
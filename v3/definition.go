@@ -0,0 +1,192 @@
+package v3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	v2 "func_decorator/v2"
+	"io"
+	"reflect"
+)
+
+// PipelineDefinition | registry 에 등록된 function 으로 Pipeline 을 조립하는 JSON 정의
+//
+//	{
+//	  "name": "calc",
+//	  "stages": [
+//	    {"tasks": [{"steps": [{"function": "add@^1"}, {"converter": "add-to-multiply"}, {"function": "multiply"}]}]}
+//	  ]
+//	}
+type PipelineDefinition struct {
+	Name   string            `json:"name"`
+	Stages []StageDefinition `json:"stages"`
+}
+
+// StageDefinition | 동시에 실행되는 Task 목록
+// Merge 는 "all" (결과를 []any 로) 또는 "first" 이며, 없으면 Task 가 하나일 때 "first", 여러 개일 때 "all" 입니다.
+type StageDefinition struct {
+	Tasks          []TaskDefinition `json:"tasks"`
+	Merge          string           `json:"merge,omitempty"`
+	MaxConcurrency int              `json:"maxConcurrency,omitempty"`
+}
+
+// TaskDefinition | 순서대로 실행되는 step 목록 (Composite Task)
+type TaskDefinition struct {
+	Steps []StepDefinition `json:"steps"`
+}
+
+// StepDefinition | Function 또는 Converter 중 하나에 registry 의 이름을 지정합니다.
+// Name 은 step 의 이름이며, 없으면 function 의 이름을 사용합니다. 같은 function 을 두 번 쓸 때는 Name 을 지정해야 합니다.
+type StepDefinition struct {
+	Name      string `json:"name,omitempty"`
+	Function  string `json:"function,omitempty"`
+	Converter string `json:"converter,omitempty"`
+}
+
+// 정의에서 사용할 수 있는 Merge
+const (
+	DefinitionMergeAll   = "all"
+	DefinitionMergeFirst = "first"
+)
+
+// ParseDefinition | r 의 JSON 을 PipelineDefinition 으로 읽습니다. 정의에 없는 필드가 있으면 에러를 반환합니다.
+func ParseDefinition(r io.Reader) (*PipelineDefinition, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var def PipelineDefinition
+	if err := dec.Decode(&def); err != nil {
+		return nil, fmt.Errorf("invalid pipeline definition: %w", err)
+	}
+	return &def, nil
+}
+
+func (s StepDefinition) ref() string {
+	if s.Function != "" {
+		return s.Function
+	}
+	return s.Converter
+}
+
+func (s StepDefinition) name() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.ref()
+}
+
+func (s StageDefinition) merge() string {
+	if s.Merge != "" {
+		return s.Merge
+	}
+	if len(s.Tasks) == 1 {
+		return DefinitionMergeFirst
+	}
+	return DefinitionMergeAll
+}
+
+// Validate | 정의의 모양과 function 의 존재, metadata 로 알 수 있는 step 사이의 타입을 검사합니다.
+// 발견한 문제를 모두 모아 에러로 반환합니다.
+func (d *PipelineDefinition) Validate(registry FunctionRegistry) error {
+	var errs []error
+	if len(d.Stages) == 0 {
+		errs = append(errs, errors.New("pipeline has no stage"))
+	}
+	for i, stage := range d.Stages {
+		if len(stage.Tasks) == 0 {
+			errs = append(errs, fmt.Errorf("stage[%d] has no task", i))
+		}
+		if m := stage.Merge; m != "" && m != DefinitionMergeAll && m != DefinitionMergeFirst {
+			errs = append(errs, fmt.Errorf("stage[%d] has unknown merge '%s'", i, m))
+		}
+		for j, task := range stage.Tasks {
+			prefix := fmt.Sprintf("stage[%d].task[%d]", i, j)
+			if _, err := d.buildTask(registry, task); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", prefix, err))
+			}
+			errs = append(errs, checkStepTypes(registry, prefix, task)...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkStepTypes | 앞 step 의 OutputType 과 다음 step 의 InputType 을 모두 알 때만 검사합니다.
+func checkStepTypes(registry FunctionRegistry, prefix string, task TaskDefinition) []error {
+	var errs []error
+	var prev FunctionMetadata
+	for k, step := range task.Steps {
+		metadata, err := registry.Describe(step.ref())
+		if err != nil {
+			prev = FunctionMetadata{}
+			continue // 존재 여부는 buildTask 에서 검사함
+		}
+		if k > 0 && prev.OutputType != nil && metadata.InputType != nil && !prev.OutputType.AssignableTo(metadata.InputType) {
+			errs = append(errs, fmt.Errorf("%s: %w", prefix, &v2.TypeMismatchError{
+				From:     task.Steps[k-1].name(),
+				FromType: prev.OutputType,
+				To:       step.name(),
+				ToType:   metadata.InputType,
+			}))
+		}
+		prev = metadata
+	}
+	return errs
+}
+
+// InputType | 첫 step 의 InputType 을 반환합니다. 알 수 없으면 nil 입니다.
+func (d *PipelineDefinition) InputType(registry FunctionRegistry) reflect.Type {
+	if len(d.Stages) == 0 || len(d.Stages[0].Tasks) == 0 || len(d.Stages[0].Tasks[0].Steps) == 0 {
+		return nil
+	}
+	metadata, err := registry.Describe(d.Stages[0].Tasks[0].Steps[0].ref())
+	if err != nil {
+		return nil
+	}
+	return metadata.InputType
+}
+
+// Build | Validate 를 통과한 정의로 Pipeline 을 만듭니다.
+func (d *PipelineDefinition) Build(registry FunctionRegistry) (*Pipeline, error) {
+	if err := d.Validate(registry); err != nil {
+		return nil, err
+	}
+	pipeline := NewPipelineBuilder()
+	for _, stage := range d.Stages {
+		sb := NewStageBuilder()
+		for _, task := range stage.Tasks {
+			built, err := d.buildTask(registry, task)
+			if err != nil {
+				return nil, err
+			}
+			sb.AddTask(built)
+		}
+		if stage.MaxConcurrency > 0 {
+			sb.MaxConcurrency(stage.MaxConcurrency)
+		}
+		merge := MergeAll
+		if stage.merge() == DefinitionMergeFirst {
+			merge = MergeFirst
+		}
+		pipeline.AddStage(sb.Build(), merge)
+	}
+	return pipeline.Build(), nil
+}
+
+// buildTask | step 을 registry 에서 찾아 TaskBuilder 로 Composite Task 를 만듭니다.
+func (d *PipelineDefinition) buildTask(registry FunctionRegistry, task TaskDefinition) (Task, error) {
+	builder := NewTaskBuilder(Composite).(*taskBuilder)
+	for k, step := range task.Steps {
+		if (step.Function == "") == (step.Converter == "") {
+			return nil, fmt.Errorf("step[%d] must have either function or converter", k)
+		}
+		fn, err := registry.GetFunction(step.ref())
+		if err != nil {
+			return nil, fmt.Errorf("step[%d]: %w", k, err)
+		}
+		if step.Function != "" {
+			builder.AddNamedFunction(step.name(), fn)
+		} else {
+			builder.AttachNamedConverter(step.name(), fn)
+		}
+	}
+	return builder.Build()
+}
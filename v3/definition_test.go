@@ -0,0 +1,105 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	v2 "func_decorator/v2"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newDefinitionTestRegistry() FunctionRegistry {
+	registry := NewSimpleFunctionRegistry()
+	registry.RegisterFunctionWithMetadata(FunctionMetadata{
+		Name:       "add",
+		Version:    "1.0.0",
+		InputType:  v2.GetGenericType[transportInput](),
+		OutputType: v2.GetGenericType[int](),
+	}, func(ctx context.Context, req any) (any, error) {
+		in := req.(transportInput)
+		return in.A + in.B, nil
+	})
+	registry.RegisterFunctionWithMetadata(FunctionMetadata{
+		Name:       "double",
+		InputType:  v2.GetGenericType[int](),
+		OutputType: v2.GetGenericType[int](),
+	}, func(ctx context.Context, req any) (any, error) {
+		return req.(int) * 2, nil
+	})
+	registry.RegisterFunctionWithMetadata(FunctionMetadata{
+		Name:       "to-input",
+		InputType:  v2.GetGenericType[int](),
+		OutputType: v2.GetGenericType[transportInput](),
+	}, func(ctx context.Context, req any) (any, error) {
+		return transportInput{A: req.(int), B: 1}, nil
+	})
+	return registry
+}
+
+func TestPipelineDefinitionBuild(t *testing.T) {
+	registry := newDefinitionTestRegistry()
+	def, err := ParseDefinition(strings.NewReader(`{
+		"name": "calc",
+		"stages": [
+			{"tasks": [{"steps": [{"function": "add@^1"}, {"converter": "to-input"}, {"name": "add-again", "function": "add"}]}]},
+			{"tasks": [{"steps": [{"function": "double"}]}, {"steps": [{"function": "to-input"}]}], "maxConcurrency": 1}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseDefinition() error = %v", err)
+	}
+	if got := def.InputType(registry); got != v2.GetGenericType[transportInput]() {
+		t.Errorf("InputType() = %v", got)
+	}
+
+	pipeline, err := def.Build(registry)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	// (1 + 2) -> {3, 1} -> 4 -> [8, {4, 1}]
+	res, err := pipeline.Run(context.Background(), transportInput{A: 1, B: 2})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if want := []any{8, transportInput{A: 4, B: 1}}; !reflect.DeepEqual(res, want) {
+		t.Errorf("Run() = %v, want %v", res, want)
+	}
+
+	dot := PlanDOT(def.Name, pipeline.Plan())
+	for _, want := range []string{`digraph "calc"`, `label="add-again", shape=box`, `label="to-input", shape=ellipse`, "n4 -> n5;", "n5 -> n6;", "n6 -> n9;", "n6 -> n11;"} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("PlanDOT() does not contain %q\n%s", want, dot)
+		}
+	}
+}
+
+func TestPipelineDefinitionValidate(t *testing.T) {
+	registry := newDefinitionTestRegistry()
+	if _, err := ParseDefinition(strings.NewReader(`{"stages": [], "unknown": 1}`)); err == nil {
+		t.Errorf("ParseDefinition() with unknown field should fail")
+	}
+
+	def := &PipelineDefinition{Stages: []StageDefinition{
+		{Tasks: []TaskDefinition{{Steps: []StepDefinition{{Function: "add"}, {Function: "to-input"}, {Function: "missing"}}}}},
+		{Tasks: []TaskDefinition{{Steps: []StepDefinition{{Function: "double"}, {Function: "add"}}}}, Merge: "last"},
+		{},
+	}}
+	err := def.Validate(registry)
+	if !errors.Is(err, ErrFunctionNotFound) || !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Validate() error = %v, want not found and type mismatch", err)
+	}
+	for _, want := range []string{
+		"stage[0].task[0]: step[2]: function not found: missing",
+		"stage[1].task[0]: type mismatch: 'double' returns int but 'add' requires v3.transportInput",
+		"stage[1] has unknown merge 'last'",
+		"stage[2] has no task",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() error does not contain %q\n%v", want, err)
+		}
+	}
+	if _, err := def.Build(registry); err == nil {
+		t.Errorf("Build() should fail when Validate fails")
+	}
+}
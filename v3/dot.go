@@ -0,0 +1,70 @@
+package v3
+
+import (
+	"fmt"
+	v2 "func_decorator/v2"
+	"strings"
+)
+
+// PlanDOT | 실행 계획을 Graphviz DOT 으로 반환합니다.
+// task, stage 는 cluster 로, function, converter 는 노드로 그리며 Parallel 인 stage 의 Task 는 나란히 연결됩니다.
+func PlanDOT(name string, plan *v2.PlanNode) string {
+	w := &dotWriter{}
+	w.sb.WriteString(fmt.Sprintf("digraph %q {\n", name))
+	w.sb.WriteString("  rankdir=LR;\n")
+	w.write(plan, 1)
+	w.sb.WriteString("}\n")
+	return w.sb.String()
+}
+
+type dotWriter struct {
+	sb  strings.Builder
+	seq int
+}
+
+// write | plan 을 그리고, 앞 노드에서 연결될 노드(entries)와 다음 노드로 연결할 노드(exits)를 반환합니다.
+func (w *dotWriter) write(plan *v2.PlanNode, depth int) (entries, exits []string) {
+	indent := strings.Repeat("  ", depth)
+	w.seq++
+	if len(plan.Children) == 0 {
+		id := fmt.Sprintf("n%d", w.seq)
+		label := plan.Name
+		if plan.ReqType != nil || plan.ResType != nil {
+			label += fmt.Sprintf("\n(%s -> %s)", metadataTypeName(plan.ReqType), metadataTypeName(plan.ResType))
+		}
+		w.sb.WriteString(fmt.Sprintf("%s%s [label=%q, shape=%s];\n", indent, id, label, dotShape(plan.Kind)))
+		return []string{id}, []string{id}
+	}
+
+	w.sb.WriteString(fmt.Sprintf("%ssubgraph cluster_%d {\n", indent, w.seq))
+	w.sb.WriteString(fmt.Sprintf("%s  label=%q;\n", indent, fmt.Sprintf("%s %s", plan.Kind, plan.Name)))
+	for i, child := range plan.Children {
+		childEntries, childExits := w.write(child, depth+1)
+		switch {
+		case plan.Parallel:
+			entries, exits = append(entries, childEntries...), append(exits, childExits...)
+		case i == 0:
+			entries, exits = childEntries, childExits
+		default:
+			for _, from := range exits {
+				for _, to := range childEntries {
+					w.sb.WriteString(fmt.Sprintf("%s  %s -> %s;\n", indent, from, to))
+				}
+			}
+			exits = childExits
+		}
+	}
+	w.sb.WriteString(indent + "}\n")
+	return entries, exits
+}
+
+func dotShape(kind v2.PlanKind) string {
+	switch kind {
+	case v2.PlanFunction:
+		return "box"
+	case v2.PlanConverter:
+		return "ellipse"
+	default:
+		return "plaintext"
+	}
+}
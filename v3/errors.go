@@ -0,0 +1,60 @@
+package v3
+
+import (
+	"errors"
+	"fmt"
+	v2 "func_decorator/v2"
+)
+
+// v2 와 같은 sentinel 에러, v2 에서 반환된 에러도 errors.Is 로 같은 종류로 판단됨
+var (
+	ErrFunctionNotFound  = v2.ErrFunctionNotFound
+	ErrDuplicateFunction = v2.ErrDuplicateFunction
+	ErrCycle             = v2.ErrCycle
+	ErrTypeMismatch      = v2.ErrTypeMismatch
+	ErrAlreadyConnected  = v2.ErrAlreadyConnected
+	ErrPanic             = v2.ErrPanic
+)
+
+// ErrInvalidInput | JSON input 을 function 의 InputType 으로 decode 하지 못했거나, 원격 호출의 input 이 거부됨
+var ErrInvalidInput = errors.New("invalid input")
+
+// errors.As 로 상세 정보를 꺼내기 위한 구조화된 에러 (v2 와 같은 타입)
+type (
+	FunctionNotFoundError  = v2.FunctionNotFoundError
+	DuplicateFunctionError = v2.DuplicateFunctionError
+	CycleError             = v2.CycleError
+	TypeMismatchError      = v2.TypeMismatchError
+	AlreadyConnectedError  = v2.AlreadyConnectedError
+	PanicError             = v2.PanicError
+)
+
+// StepError | Task 의 몇 번째 step 에서 실패했는지와 해당 호출의 실행 식별 정보를 담은 에러
+type StepError struct {
+	v2.ExecutionInfo
+	Step int
+	Name string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("step '%s' failed (%s): %v", e.Name, e.ExecutionInfo, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// StageError | Pipeline 의 몇 번째 Stage 에서 실패했는지 나타내는 에러
+type StageError struct {
+	Index int
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("stage[%d] failed: %v", e.Index, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,227 @@
+// Package grpctransport | v3 registry 의 function 을 gRPC FunctionService 로 제공하고 호출합니다.
+// gRPC 의존성이 필요한 경우에만 이 패키지를 import 하면 됩니다.
+package grpctransport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	v2 "func_decorator/v2"
+	v3 "func_decorator/v3"
+	"func_decorator/v3/grpctransport/pb"
+	"reflect"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server | registry 의 function 을 gRPC FunctionService 로 제공하는 서버
+// ctx 의 deadline 은 gRPC 가 전달하므로 function 은 client 의 deadline 이 걸린 ctx 를 받습니다.
+type Server struct {
+	pb.UnimplementedFunctionServiceServer
+	registry v3.FunctionRegistry
+}
+
+// NewServer | registry 의 function 을 제공하는 Server 를 만듭니다.
+func NewServer(registry v3.FunctionRegistry) *Server {
+	return &Server{registry: registry}
+}
+
+// RegisterServer | s 에 registry 의 FunctionService 를 등록합니다.
+func RegisterServer(s grpc.ServiceRegistrar, registry v3.FunctionRegistry) {
+	pb.RegisterFunctionServiceServer(s, NewServer(registry))
+}
+
+func (s *Server) List(ctx context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
+	list := s.registry.List()
+	if req.GetTag() != "" {
+		list = s.registry.FindByTag(req.GetTag())
+	}
+	res := &pb.ListResponse{Functions: make([]*pb.FunctionInfo, 0, len(list))}
+	for _, metadata := range list {
+		res.Functions = append(res.Functions, toProtoInfo(metadata.Info()))
+	}
+	return res, nil
+}
+
+func (s *Server) Describe(ctx context.Context, req *pb.DescribeRequest) (*pb.FunctionInfo, error) {
+	metadata, err := s.registry.Describe(req.GetName())
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toProtoInfo(metadata.Info()), nil
+}
+
+func (s *Server) Call(ctx context.Context, req *pb.CallRequest) (*pb.CallResponse, error) {
+	output, err := v3.CallJSONFunction(ctx, s.registry, req.GetName(), req.GetInput())
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &pb.CallResponse{Output: output}, nil
+}
+
+// Code | err 를 gRPC 상태 코드로 변환합니다.
+//   - v3.ErrFunctionNotFound : NotFound
+//   - v3.ErrDuplicateFunction : AlreadyExists
+//   - v3.ErrTypeMismatch, v3.ErrInvalidInput : InvalidArgument
+//   - v3.ErrCycle : FailedPrecondition
+//   - v3.ErrPanic : Internal
+//   - context.DeadlineExceeded, context.Canceled : DeadlineExceeded, Canceled
+//   - 그 밖의 function 에러 : Unknown
+func Code(err error) codes.Code {
+	switch {
+	case err == nil:
+		return codes.OK
+	case errors.Is(err, v3.ErrFunctionNotFound):
+		return codes.NotFound
+	case errors.Is(err, v3.ErrDuplicateFunction):
+		return codes.AlreadyExists
+	case errors.Is(err, v3.ErrTypeMismatch), errors.Is(err, v3.ErrInvalidInput):
+		return codes.InvalidArgument
+	case errors.Is(err, v3.ErrCycle):
+		return codes.FailedPrecondition
+	case errors.Is(err, v3.ErrPanic):
+		return codes.Internal
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	default:
+		return status.Code(err)
+	}
+}
+
+func toGRPCError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(Code(err), err.Error())
+}
+
+// fromGRPCError | gRPC 에러를 Code 의 반대 방향으로 sentinel 에러와 함께 감쌉니다.
+// InvalidArgument 는 원인(타입 불일치, 잘못된 JSON)을 구분할 수 없으므로 v3.ErrInvalidInput 으로,
+// FailedPrecondition, Internal 처럼 원인을 특정할 수 없는 코드는 sentinel 없이 감쌉니다.
+func fromGRPCError(name string, err error) error {
+	var sentinel error
+	switch status.Code(err) {
+	case codes.NotFound:
+		sentinel = v3.ErrFunctionNotFound
+	case codes.AlreadyExists:
+		sentinel = v3.ErrDuplicateFunction
+	case codes.InvalidArgument:
+		sentinel = v3.ErrInvalidInput
+	case codes.DeadlineExceeded:
+		sentinel = context.DeadlineExceeded
+	case codes.Canceled:
+		sentinel = context.Canceled
+	default:
+		return fmt.Errorf("remote function '%s': %w", name, err)
+	}
+	return fmt.Errorf("remote function '%s': %w: %w", name, sentinel, err)
+}
+
+func toProtoInfo(info v3.FunctionInfo) *pb.FunctionInfo {
+	return &pb.FunctionInfo{
+		Name:        info.Name,
+		Description: info.Description,
+		InputType:   info.InputType,
+		OutputType:  info.OutputType,
+		Version:     info.Version,
+		Tags:        info.Tags,
+		Owner:       info.Owner,
+		Idempotent:  info.Idempotent,
+	}
+}
+
+func fromProtoInfo(info *pb.FunctionInfo) v3.FunctionInfo {
+	return v3.FunctionInfo{
+		Name:        info.GetName(),
+		Description: info.GetDescription(),
+		InputType:   info.GetInputType(),
+		OutputType:  info.GetOutputType(),
+		Version:     info.GetVersion(),
+		Tags:        info.GetTags(),
+		Owner:       info.GetOwner(),
+		Idempotent:  info.GetIdempotent(),
+	}
+}
+
+// Client | Server 로 제공되는 function 을 호출하는 client
+type Client struct {
+	client pb.FunctionServiceClient
+}
+
+// NewClient | conn 으로 FunctionService 를 호출하는 client 를 만듭니다.
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{client: pb.NewFunctionServiceClient(conn)}
+}
+
+// List | 원격 registry 에 등록된 function 의 FunctionInfo 목록을 반환합니다. tag 가 있으면 해당 tag 의 function 만 반환합니다.
+func (c *Client) List(ctx context.Context, tag string) ([]v3.FunctionInfo, error) {
+	res, err := c.client.List(ctx, &pb.ListRequest{Tag: tag})
+	if err != nil {
+		return nil, fromGRPCError("", err)
+	}
+	infos := make([]v3.FunctionInfo, 0, len(res.GetFunctions()))
+	for _, info := range res.GetFunctions() {
+		infos = append(infos, fromProtoInfo(info))
+	}
+	return infos, nil
+}
+
+// Describe | 원격 registry 의 name 에 대한 FunctionInfo 를 반환합니다.
+func (c *Client) Describe(ctx context.Context, name string) (v3.FunctionInfo, error) {
+	info, err := c.client.Describe(ctx, &pb.DescribeRequest{Name: name})
+	if err != nil {
+		return v3.FunctionInfo{}, fromGRPCError(name, err)
+	}
+	return fromProtoInfo(info), nil
+}
+
+// Function | 원격의 name 을 호출하는 FunctionType 을 반환합니다.
+// 응답은 outputType 으로 decode 하며, outputType 이 nil 이면 any (map[string]any, float64 등) 로 decode 합니다.
+func (c *Client) Function(name string, outputType reflect.Type) v3.FunctionType {
+	return func(ctx context.Context, req any) (any, error) {
+		input, err := json.Marshal(req)
+		if err != nil {
+			return nil, fmt.Errorf("remote function '%s': failed to encode input: %w", name, err)
+		}
+		res, err := c.client.Call(ctx, &pb.CallRequest{Name: name, Input: input})
+		if err != nil {
+			return nil, fromGRPCError(name, err)
+		}
+		output, err := v3.DecodeJSON(res.GetOutput(), outputType)
+		if err != nil {
+			return nil, fmt.Errorf("remote function '%s': failed to decode output: %w", name, err)
+		}
+		return output, nil
+	}
+}
+
+// Function | 원격의 name 을 호출하고 응답을 Res 로 decode 하는 FunctionType 을 반환합니다.
+func Function[Res any](c *Client, name string) v3.FunctionType {
+	return c.Function(name, v2.GetGenericType[Res]())
+}
+
+// AnyFunction | 원격의 name 을 호출하는 v2.AnyFunction 을 반환합니다. v2 그래프의 노드로 등록할 수 있습니다.
+func AnyFunction[Req, Res any](c *Client, name string) (v2.AnyFunction, error) {
+	return v2.NewAnyFunction(v2.GetGenericType[Req](), v2.GetGenericType[Res](), Function[Res](c, name))
+}
+
+// RegisterRemote | 원격 registry 의 function 을 metadata 와 함께 registry 에 등록합니다.
+// 타입 정보는 전달되지 않으므로 응답은 any 로 decode 됩니다. 타입이 필요하면 Function 으로 직접 등록합니다.
+func (c *Client) RegisterRemote(ctx context.Context, registry v3.FunctionRegistry) error {
+	infos, err := c.List(ctx, "")
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		name := v2.VersionedName(info.Name, info.Version)
+		if err := registry.RegisterFunctionWithMetadata(info.Metadata(), c.Function(name, nil)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package grpctransport
+
+import (
+	"context"
+	"errors"
+	v2 "func_decorator/v2"
+	v3 "func_decorator/v3"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type addInput struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+type addOutput struct {
+	Sum int `json:"sum"`
+}
+
+// newTestRegistry | add@1.0.0 (math), "boom" 에러를 반환하는 fail, "kaboom" 패닉이 나는 explode,
+// ctx 가 끝날 때까지 기다리는 slow 가 등록된 registry
+func newTestRegistry() v3.FunctionRegistry {
+	registry := v3.NewSimpleFunctionRegistry()
+	registry.RegisterFunctionWithMetadata(v3.FunctionMetadata{
+		Name:       "add",
+		Version:    "1.0.0",
+		Tags:       []string{"math"},
+		InputType:  v2.GetGenericType[addInput](),
+		OutputType: v2.GetGenericType[addOutput](),
+	}, func(ctx context.Context, req any) (any, error) {
+		in := req.(addInput)
+		return addOutput{Sum: in.A + in.B}, nil
+	})
+	registry.RegisterFunction("fail", func(ctx context.Context, req any) (any, error) {
+		return nil, errors.New("boom")
+	})
+	registry.RegisterFunction("explode", func(ctx context.Context, req any) (any, error) {
+		panic("kaboom")
+	})
+	registry.RegisterFunction("slow", func(ctx context.Context, req any) (any, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			return nil, errors.New("deadline is not propagated")
+		}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	return registry
+}
+
+func newTestClient(t *testing.T) *Client {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	RegisterServer(server, newTestRegistry())
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("DialContext() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewClient(conn)
+}
+
+func TestFunction(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	infos, err := client.List(ctx, "math")
+	if err != nil || len(infos) != 1 || infos[0].Name != "add" || infos[0].InputType != "grpctransport.addInput" {
+		t.Errorf("List() = %+v, %v", infos, err)
+	}
+
+	// v3 CompositeTask 의 한 단계로 원격 function 을 사용
+	task, err := v3.NewTaskBuilder(v3.Composite).
+		AddFunction(Function[addOutput](client, "add@^1")).
+		AttachConverter(func(ctx context.Context, req any) (any, error) {
+			return addInput{A: req.(addOutput).Sum, B: 10}, nil
+		}).
+		AddLastFunction(Function[addOutput](client, "add")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	res, err := task.Execute(ctx, addInput{A: 1, B: 2})
+	if err != nil || res != (addOutput{Sum: 13}) {
+		t.Errorf("Execute() = %v, %v", res, err)
+	}
+
+	// v2 그래프의 노드로 원격 function 을 사용
+	add, err := AnyFunction[addInput, addOutput](client, "add")
+	if err != nil {
+		t.Fatalf("AnyFunction() error = %v", err)
+	}
+	graph := v2.NewFunctionRegistry()
+	graph.RegisterFunction("remote-add", add)
+	results, err := v2.NewFunctionChainExecutor(graph, nil).Execute(ctx, "remote-add", addInput{A: 2, B: 3})
+	if err != nil || len(results.Slice()) != 1 || results.Slice()[0].Res != (addOutput{Sum: 5}) {
+		t.Errorf("FunctionChainExecutor.Execute() error = %v", err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.Function("slow", nil)(ctx, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("slow() error = %v, want deadline exceeded", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    any
+		code     codes.Code
+		sentinel error
+	}{
+		{name: "missing", code: codes.NotFound, sentinel: v3.ErrFunctionNotFound},
+		{name: "add", input: "not an object", code: codes.InvalidArgument, sentinel: v3.ErrInvalidInput},
+		{name: "fail", code: codes.Unknown},
+		{name: "explode", code: codes.Internal},
+	}
+	for _, tt := range tests {
+		_, err := client.Function(tt.name, nil)(context.Background(), tt.input)
+		if code := status.Code(err); code != tt.code {
+			t.Errorf("%s() code = %v, want %v", tt.name, code, tt.code)
+		}
+		if tt.sentinel != nil && !errors.Is(err, tt.sentinel) {
+			t.Errorf("%s() error = %v, want %v", tt.name, err, tt.sentinel)
+		}
+	}
+
+	if Code(&v3.FunctionNotFoundError{Name: "x"}) != codes.NotFound || Code(&v3.DuplicateFunctionError{Name: "x"}) != codes.AlreadyExists {
+		t.Errorf("Code() does not map registry errors")
+	}
+	// FailedPrecondition 은 원인을 알 수 없으므로 ErrCycle 로 풀지 않음
+	if err := fromGRPCError("x", status.Error(codes.FailedPrecondition, "x")); errors.Is(err, v3.ErrCycle) {
+		t.Errorf("fromGRPCError() = %v, should not be ErrCycle", err)
+	}
+}
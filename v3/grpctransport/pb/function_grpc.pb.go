@@ -0,0 +1,196 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: v3/grpctransport/pb/function.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	FunctionService_List_FullMethodName     = "/func_decorator.v3.FunctionService/List"
+	FunctionService_Describe_FullMethodName = "/func_decorator.v3.FunctionService/Describe"
+	FunctionService_Call_FullMethodName     = "/func_decorator.v3.FunctionService/Call"
+)
+
+// FunctionServiceClient is the client API for FunctionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FunctionService | registry 에 등록된 function 을 gRPC 로 제공하는 서비스
+type FunctionServiceClient interface {
+	// List | 등록된 function 의 metadata 목록, tag 가 있으면 해당 tag 의 function 만 반환
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Describe | name 의 metadata
+	Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*FunctionInfo, error)
+	// Call | name 의 function 을 호출, input, output 은 JSON
+	Call(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error)
+}
+
+type functionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFunctionServiceClient(cc grpc.ClientConnInterface) FunctionServiceClient {
+	return &functionServiceClient{cc}
+}
+
+func (c *functionServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, FunctionService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *functionServiceClient) Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*FunctionInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FunctionInfo)
+	err := c.cc.Invoke(ctx, FunctionService_Describe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *functionServiceClient) Call(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CallResponse)
+	err := c.cc.Invoke(ctx, FunctionService_Call_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FunctionServiceServer is the server API for FunctionService service.
+// All implementations must embed UnimplementedFunctionServiceServer
+// for forward compatibility
+//
+// FunctionService | registry 에 등록된 function 을 gRPC 로 제공하는 서비스
+type FunctionServiceServer interface {
+	// List | 등록된 function 의 metadata 목록, tag 가 있으면 해당 tag 의 function 만 반환
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Describe | name 의 metadata
+	Describe(context.Context, *DescribeRequest) (*FunctionInfo, error)
+	// Call | name 의 function 을 호출, input, output 은 JSON
+	Call(context.Context, *CallRequest) (*CallResponse, error)
+	mustEmbedUnimplementedFunctionServiceServer()
+}
+
+// UnimplementedFunctionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFunctionServiceServer struct {
+}
+
+func (UnimplementedFunctionServiceServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedFunctionServiceServer) Describe(context.Context, *DescribeRequest) (*FunctionInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Describe not implemented")
+}
+func (UnimplementedFunctionServiceServer) Call(context.Context, *CallRequest) (*CallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Call not implemented")
+}
+func (UnimplementedFunctionServiceServer) mustEmbedUnimplementedFunctionServiceServer() {}
+
+// UnsafeFunctionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FunctionServiceServer will
+// result in compilation errors.
+type UnsafeFunctionServiceServer interface {
+	mustEmbedUnimplementedFunctionServiceServer()
+}
+
+func RegisterFunctionServiceServer(s grpc.ServiceRegistrar, srv FunctionServiceServer) {
+	s.RegisterService(&FunctionService_ServiceDesc, srv)
+}
+
+func _FunctionService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FunctionServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FunctionService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FunctionServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FunctionService_Describe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FunctionServiceServer).Describe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FunctionService_Describe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FunctionServiceServer).Describe(ctx, req.(*DescribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FunctionService_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FunctionServiceServer).Call(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FunctionService_Call_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FunctionServiceServer).Call(ctx, req.(*CallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FunctionService_ServiceDesc is the grpc.ServiceDesc for FunctionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FunctionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "func_decorator.v3.FunctionService",
+	HandlerType: (*FunctionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _FunctionService_List_Handler,
+		},
+		{
+			MethodName: "Describe",
+			Handler:    _FunctionService_Describe_Handler,
+		},
+		{
+			MethodName: "Call",
+			Handler:    _FunctionService_Call_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "v3/grpctransport/pb/function.proto",
+}
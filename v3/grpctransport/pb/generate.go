@@ -0,0 +1,4 @@
+// Package pb | v3 FunctionService 의 gRPC 정의 (function.proto 에서 생성)
+package pb
+
+//go:generate protoc -I ../../.. --go_out=../../.. --go_opt=paths=source_relative --go-grpc_out=../../.. --go-grpc_opt=paths=source_relative v3/grpctransport/pb/function.proto
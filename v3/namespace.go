@@ -0,0 +1,189 @@
+package v3
+
+import (
+	"errors"
+	v2 "func_decorator/v2"
+	"strings"
+)
+
+// namespace 접근 제어는 v2 와 같은 타입을 사용함
+type (
+	AccessOperation = v2.AccessOperation
+	AccessHook      = v2.AccessHook
+)
+
+const (
+	AccessRegister = v2.AccessRegister
+	AccessLookup   = v2.AccessLookup
+	AccessExport   = v2.AccessExport
+)
+
+// NamespacedRegistry | 하나의 FunctionRegistry 를 namespace 로 나누어 사용하는 view
+// "add" 는 이 namespace 의 "billing.add" 로 등록되며, 조회 시 이 namespace 에 없으면 상위 namespace 에서 찾습니다.
+// "invoice.add" 처럼 하위 namespace 를 포함한 이름으로 하위 namespace 의 function 에 접근할 수 있습니다.
+// List, FindByTag 가 반환하는 metadata 의 Name 은 이 namespace 기준의 이름입니다.
+type NamespacedRegistry struct {
+	root      FunctionRegistry
+	namespace string
+	access    *v2.AccessControl
+}
+
+// NewNamespacedRegistry | root 를 최상위 namespace("") 로 사용하는 registry 를 만듭니다.
+func NewNamespacedRegistry(root FunctionRegistry) *NamespacedRegistry {
+	return &NamespacedRegistry{root: root, access: v2.NewAccessControl()}
+}
+
+// Namespace | 하위 namespace 의 registry 를 반환합니다. name 은 "invoice" 또는 "invoice.tax" 처럼 지정합니다.
+func (r *NamespacedRegistry) Namespace(name string) *NamespacedRegistry {
+	return &NamespacedRegistry{root: r.root, namespace: v2.QualifiedName(r.namespace, name), access: r.access}
+}
+
+// Name | namespace 의 이름을 반환합니다. 최상위는 "" 입니다.
+func (r *NamespacedRegistry) Name() string {
+	return r.namespace
+}
+
+// AddAccessHook | 이 namespace (와 하위 namespace) 의 function 에 접근할 때 호출할 hook 을 추가합니다.
+func (r *NamespacedRegistry) AddAccessHook(hook AccessHook) {
+	r.access.AddHook(r.namespace, hook)
+}
+
+func (r *NamespacedRegistry) RegisterFunction(name string, fn FunctionType) error {
+	return r.RegisterFunctionWithMetadata(FunctionMetadata{Name: name}, fn)
+}
+
+func (r *NamespacedRegistry) RegisterFunctionWithMetadata(metadata FunctionMetadata, fn FunctionType) error {
+	metadata.Name = v2.QualifiedName(r.namespace, metadata.Name)
+	if err := r.access.Check(AccessRegister, metadata.Name); err != nil {
+		return err
+	}
+	return r.root.RegisterFunctionWithMetadata(metadata, fn)
+}
+
+func (r *NamespacedRegistry) ReplaceFunction(name string, fn FunctionType) error {
+	return r.ReplaceFunctionWithMetadata(FunctionMetadata{Name: name}, fn)
+}
+
+func (r *NamespacedRegistry) ReplaceFunctionWithMetadata(metadata FunctionMetadata, fn FunctionType) error {
+	metadata.Name = v2.QualifiedName(r.namespace, metadata.Name)
+	if err := r.access.Check(AccessRegister, metadata.Name); err != nil {
+		return err
+	}
+	return r.root.ReplaceFunctionWithMetadata(metadata, fn)
+}
+
+// GetFunction | 이 namespace 부터 상위 namespace 순으로 name 을 찾습니다.
+func (r *NamespacedRegistry) GetFunction(name string) (FunctionType, error) {
+	full, err := r.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return r.root.GetFunction(full)
+}
+
+func (r *NamespacedRegistry) DeregisterFunction(name string) {
+	full := v2.QualifiedName(r.namespace, name)
+	if r.access.Check(AccessRegister, full) != nil {
+		return
+	}
+	r.root.DeregisterFunction(full)
+}
+
+// Versions | 이 namespace 부터 상위 namespace 순으로, 처음 버전이 발견된 namespace 의 버전을 반환합니다.
+func (r *NamespacedRegistry) Versions(name string) []string {
+	for ns := r.namespace; ; ns = v2.ParentNamespace(ns) {
+		full := v2.QualifiedName(ns, name)
+		if versions := r.root.Versions(full); len(versions) > 0 && r.access.Check(AccessLookup, full) == nil {
+			return versions
+		}
+		if ns == "" {
+			return []string{}
+		}
+	}
+}
+
+func (r *NamespacedRegistry) List() []FunctionMetadata {
+	return r.relative(r.root.List())
+}
+
+func (r *NamespacedRegistry) FindByTag(tag string) []FunctionMetadata {
+	return r.relative(r.root.FindByTag(tag))
+}
+
+// Describe | GetFunction 과 같은 순서로 name 을 찾아 metadata 를 반환합니다. Name 은 namespace 가 포함된 이름입니다.
+func (r *NamespacedRegistry) Describe(name string) (FunctionMetadata, error) {
+	full, err := r.resolve(name)
+	if err != nil {
+		return FunctionMetadata{}, err
+	}
+	return r.root.Describe(full)
+}
+
+// ExportTo | 이 namespace 의 function 을 metadata 와 함께 dst 의 namespace 로 복사합니다.
+// 복사하기 전에 모든 function 의 export 권한을 확인하며, 복사 중 실패하면 dst 에 복사한 function 을 모두 삭제합니다.
+func (r *NamespacedRegistry) ExportTo(dst FunctionRegistry, namespace string) error {
+	list := r.List()
+	fns := make([]FunctionType, 0, len(list))
+	for _, metadata := range list {
+		full := v2.VersionedName(v2.QualifiedName(r.namespace, metadata.Name), metadata.Version)
+		if err := r.access.Check(AccessExport, full); err != nil {
+			return err
+		}
+		fn, err := r.root.GetFunction(full)
+		if err != nil {
+			return err
+		}
+		fns = append(fns, fn)
+	}
+
+	exported := make([]string, 0, len(list))
+	for i, metadata := range list {
+		metadata.Name = v2.QualifiedName(namespace, metadata.Name)
+		if err := dst.RegisterFunctionWithMetadata(metadata, fns[i]); err != nil {
+			for _, name := range exported {
+				dst.DeregisterFunction(name)
+			}
+			return err
+		}
+		exported = append(exported, v2.VersionedName(metadata.Name, metadata.Version))
+	}
+	return nil
+}
+
+// resolve | 이 namespace 부터 상위 namespace 순으로 name 을 찾아 버전이 포함된 전체 이름을 반환합니다.
+func (r *NamespacedRegistry) resolve(name string) (string, error) {
+	for ns := r.namespace; ; ns = v2.ParentNamespace(ns) {
+		metadata, err := r.root.Describe(v2.QualifiedName(ns, name))
+		if err == nil {
+			full := v2.VersionedName(metadata.Name, metadata.Version)
+			if err := r.access.Check(AccessLookup, full); err != nil {
+				return "", err
+			}
+			return full, nil
+		}
+		if !errors.Is(err, ErrFunctionNotFound) {
+			return "", err
+		}
+		if ns == "" {
+			return "", &FunctionNotFoundError{Name: v2.QualifiedName(r.namespace, name)}
+		}
+	}
+}
+
+// relative | 이 namespace 에 속하고 접근이 허용된 metadata 만 namespace 를 뺀 이름으로 반환합니다.
+func (r *NamespacedRegistry) relative(list []FunctionMetadata) []FunctionMetadata {
+	filtered := make([]FunctionMetadata, 0, len(list))
+	for _, metadata := range list {
+		if r.access.Check(AccessLookup, v2.VersionedName(metadata.Name, metadata.Version)) != nil {
+			continue
+		}
+		if r.namespace != "" {
+			if !strings.HasPrefix(metadata.Name, r.namespace+".") {
+				continue
+			}
+			metadata.Name = strings.TrimPrefix(metadata.Name, r.namespace+".")
+		}
+		filtered = append(filtered, metadata)
+	}
+	return filtered
+}
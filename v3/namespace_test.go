@@ -0,0 +1,143 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNamespacedRegistry(t *testing.T) {
+	constant := func(v int) FunctionType {
+		return func(ctx context.Context, a any) (any, error) { return v, nil }
+	}
+	root := NewNamespacedRegistry(NewSimpleFunctionRegistry())
+	billing := root.Namespace("billing")
+	invoice := billing.Namespace("invoice")
+
+	var registry FunctionRegistry = invoice
+	root.RegisterFunction("add", constant(0))
+	billing.RegisterFunctionWithMetadata(FunctionMetadata{Name: "add", Version: "1.0.0", Tags: []string{"math"}}, constant(1))
+	registry.RegisterFunction("tax", constant(2))
+
+	call := func(r FunctionRegistry, name string) any {
+		fn, err := r.GetFunction(name)
+		if err != nil {
+			t.Errorf("GetFunction(%q) error = %v", name, err)
+			return nil
+		}
+		res, _ := fn(context.Background(), nil)
+		return res
+	}
+	if got := call(invoice, "add"); got != 1 {
+		t.Errorf("invoice add = %v, want billing.add", got)
+	}
+	if got := call(root, "add"); got != 0 {
+		t.Errorf("root add = %v, want add", got)
+	}
+	if got := call(root, "billing.invoice.tax"); got != 2 {
+		t.Errorf("root billing.invoice.tax = %v", got)
+	}
+	if _, err := root.GetFunction("tax"); !errors.Is(err, ErrFunctionNotFound) {
+		t.Errorf("GetFunction(tax) error = %v, want ErrFunctionNotFound", err)
+	}
+	if metadata, err := invoice.Describe("add@^1.0.0"); err != nil || metadata.Name != "billing.add" {
+		t.Errorf("Describe() = (%v, %v), want billing.add", metadata, err)
+	}
+
+	names := func(list []FunctionMetadata) []string {
+		out := make([]string, 0, len(list))
+		for _, m := range list {
+			out = append(out, m.Name)
+		}
+		return out
+	}
+	if got := names(billing.List()); !reflect.DeepEqual(got, []string{"add", "invoice.tax"}) {
+		t.Errorf("List() = %v", got)
+	}
+	if got := names(billing.FindByTag("math")); !reflect.DeepEqual(got, []string{"add"}) {
+		t.Errorf("FindByTag() = %v", got)
+	}
+
+	dst := NewSimpleFunctionRegistry()
+	if err := billing.ExportTo(dst, "shared"); err != nil {
+		t.Fatalf("ExportTo() error = %v", err)
+	}
+	if got := dst.Versions("shared.add"); !reflect.DeepEqual(got, []string{"1.0.0"}) {
+		t.Errorf("ExportTo() versions = %v", got)
+	}
+	if got := call(dst, "shared.invoice.tax"); got != 2 {
+		t.Errorf("ExportTo() tax = %v", got)
+	}
+}
+
+func TestNamespacedRegistryAccessHook(t *testing.T) {
+	errDenied := errors.New("denied")
+	root := NewNamespacedRegistry(NewSimpleFunctionRegistry())
+	secret := root.Namespace("secret")
+	secret.RegisterFunction("key", func(ctx context.Context, a any) (any, error) { return "k", nil })
+	secret.AddAccessHook(func(op AccessOperation, namespace, name string) error {
+		if op != AccessRegister {
+			return errDenied
+		}
+		return nil
+	})
+
+	if _, err := root.GetFunction("secret.key"); !errors.Is(err, errDenied) {
+		t.Errorf("GetFunction() error = %v, want denied", err)
+	}
+	if len(root.List()) != 0 {
+		t.Errorf("List() = %v, want denied functions hidden", root.List())
+	}
+
+	internal := root.Namespace("internal")
+	internal.RegisterFunction("job", func(ctx context.Context, a any) (any, error) { return nil, nil })
+	internal.AddAccessHook(func(op AccessOperation, namespace, name string) error {
+		if op == AccessExport {
+			return errDenied
+		}
+		return nil
+	})
+	if _, err := internal.GetFunction("job"); err != nil {
+		t.Errorf("GetFunction() error = %v", err)
+	}
+	if err := internal.ExportTo(NewSimpleFunctionRegistry(), "copy"); !errors.Is(err, errDenied) {
+		t.Errorf("ExportTo() error = %v, want denied", err)
+	}
+}
+
+func TestNamespacedRegistryExportToFailure(t *testing.T) {
+	errDenied := errors.New("denied")
+	identity := func(ctx context.Context, a any) (any, error) { return a, nil }
+	billing := NewNamespacedRegistry(NewSimpleFunctionRegistry()).Namespace("billing")
+	billing.RegisterFunction("add", identity)
+	billing.RegisterFunction("secret", identity)
+	billing.RegisterFunction("tax", identity)
+
+	// export 권한이 없는 function 이 있으면 아무것도 복사하지 않음
+	denied := NewNamespacedRegistry(NewSimpleFunctionRegistry()).Namespace("billing")
+	denied.RegisterFunction("add", identity)
+	denied.RegisterFunction("secret", identity)
+	denied.AddAccessHook(func(op AccessOperation, namespace, name string) error {
+		if op == AccessExport && name == "billing.secret" {
+			return errDenied
+		}
+		return nil
+	})
+	dst := NewSimpleFunctionRegistry()
+	if err := denied.ExportTo(dst, "shared"); !errors.Is(err, errDenied) {
+		t.Errorf("ExportTo() error = %v, want denied", err)
+	}
+	if got := dst.List(); len(got) != 0 {
+		t.Errorf("ExportTo() should not copy any function, got %v", got)
+	}
+
+	// 복사 중 실패하면 복사한 function 을 삭제하고, 원래 있던 function 은 남김
+	dst.RegisterFunction("shared.secret", identity)
+	if err := billing.ExportTo(dst, "shared"); !errors.Is(err, ErrDuplicateFunction) {
+		t.Errorf("ExportTo() error = %v, want ErrDuplicateFunction", err)
+	}
+	if got := dst.List(); len(got) != 1 || got[0].Name != "shared.secret" {
+		t.Errorf("ExportTo() should roll back copied functions, got %v", got)
+	}
+}
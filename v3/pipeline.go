@@ -0,0 +1,99 @@
+package v3
+
+import (
+	"context"
+	"fmt"
+	v2 "func_decorator/v2"
+	"time"
+)
+
+// MergeFunc | Stage 의 결과([]any) 를 다음 Stage 의 입력으로 합치는 func
+type MergeFunc func(ctx context.Context, results []any) (any, error)
+
+// MergeAll | Stage 의 결과를 그대로 다음 Stage 의 입력으로 넘깁니다.
+func MergeAll(_ context.Context, results []any) (any, error) {
+	return results, nil
+}
+
+// MergeFirst | Stage 의 첫 번째 결과만 다음 Stage 의 입력으로 넘깁니다.
+func MergeFirst(_ context.Context, results []any) (any, error) {
+	if len(results) == 0 {
+		return nil, fmt.Errorf("MergeFirst - stage has no results")
+	}
+	return results[0], nil
+}
+
+type pipelineStage struct {
+	stage Stage
+	merge MergeFunc
+}
+
+// Pipeline | Stage 들을 순서대로 실행하며, Stage 사이의 MergeFunc 로 다음 Stage 의 입력을 만듭니다.
+// 하나의 Stage 라도 실패하면 바로 중단합니다.
+type Pipeline struct {
+	stages []pipelineStage
+}
+
+type PipelineBuilder interface {
+	AddStage(stage Stage, merge MergeFunc) PipelineBuilder
+	Build() *Pipeline
+}
+
+type pipelineBuilder struct {
+	pipeline *Pipeline
+}
+
+func NewPipelineBuilder() PipelineBuilder {
+	return &pipelineBuilder{pipeline: &Pipeline{}}
+}
+
+// AddStage | stage 와 stage 의 결과를 합칠 merge 를 추가합니다.
+// merge 가 nil 이면 MergeAll 을 사용하며, 마지막 stage 의 merge 결과가 Pipeline 의 결과가 됩니다.
+func (b *pipelineBuilder) AddStage(stage Stage, merge MergeFunc) PipelineBuilder {
+	if merge == nil {
+		merge = MergeAll
+	}
+	b.pipeline.stages = append(b.pipeline.stages, pipelineStage{stage: stage, merge: merge})
+	return b
+}
+
+func (b *pipelineBuilder) Build() *Pipeline {
+	return b.pipeline
+}
+
+// Run | Stage 들을 순서대로 실행합니다.
+// 모든 Stage 는 같은 run 에 속하며, 실패한 Stage 는 *StageError 로 감싸서 반환합니다.
+func (p *Pipeline) Run(ctx context.Context, input any) (res any, err error) {
+	ctx, started := v2.StartRun(ctx)
+	if started {
+		begin := time.Now()
+		defer func() {
+			v2.PublishEvent(ctx, v2.Event{Type: v2.RunFinished, Req: input, Res: res, Err: err, Duration: time.Since(begin)})
+		}()
+	}
+	ctx, _ = v2.WithInvocation(ctx)
+
+	current := input
+	for i, s := range p.stages {
+		if err := ctx.Err(); err != nil {
+			return nil, &StageError{Index: i, Err: err}
+		}
+		results, err := s.stage.Run(ctx, current)
+		if err != nil {
+			return nil, &StageError{Index: i, Err: err}
+		}
+		if current, err = s.merge(ctx, results); err != nil {
+			return nil, &StageError{Index: i, Err: fmt.Errorf("merge failed: %w", err)}
+		}
+	}
+	return current, nil
+}
+
+// Plan | Stage 들을 실행 순서대로 나열한 계획을 반환합니다.
+func (p *Pipeline) Plan() *v2.PlanNode {
+	plan := &v2.PlanNode{Kind: PlanStage, Name: "pipeline"}
+	for i, s := range p.stages {
+		plan.Children = append(plan.Children, namedPlan(fmt.Sprintf("stage[%d]", i), s.stage))
+	}
+	return plan
+}
@@ -0,0 +1,55 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPipelineRun(t *testing.T) {
+	add := func(n int) Task {
+		return newFuncTask(func(ctx context.Context, a any) (any, error) { return a.(int) + n, nil })
+	}
+	sum := func(ctx context.Context, results []any) (any, error) {
+		total := 0
+		for _, r := range results {
+			total += r.(int)
+		}
+		return total, nil
+	}
+
+	// 1 -> [2, 3] -> 5 -> [15] -> 15
+	pipeline := NewPipelineBuilder().
+		AddStage(NewConcurrentStage(add(1), add(2)), sum).
+		AddStage(NewConcurrentStage(add(10)), MergeFirst).
+		Build()
+
+	res, err := pipeline.Run(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if res != 15 {
+		t.Errorf("Run() = %v, want 15", res)
+	}
+}
+
+func TestPipelineEarlyExit(t *testing.T) {
+	errFail := errors.New("fail")
+	called := false
+	pipeline := NewPipelineBuilder().
+		AddStage(NewConcurrentStage(failTask(errFail)), nil).
+		AddStage(NewConcurrentStage(newFuncTask(func(ctx context.Context, a any) (any, error) {
+			called = true
+			return a, nil
+		})), nil).
+		Build()
+
+	_, err := pipeline.Run(context.Background(), 1)
+	var stageErr *StageError
+	if !errors.As(err, &stageErr) || stageErr.Index != 0 || !errors.Is(err, errFail) {
+		t.Errorf("Run() error = %v, want stage[0] error", err)
+	}
+	if called {
+		t.Errorf("next stage should not be executed after error")
+	}
+}
@@ -0,0 +1,68 @@
+package v3
+
+import (
+	"fmt"
+	v2 "func_decorator/v2"
+)
+
+// v3 에서 추가로 사용하는 실행 계획 노드의 종류
+const (
+	PlanTask  = v2.PlanKind("task")
+	PlanStage = v2.PlanKind("stage")
+)
+
+// Plannable | 실행하지 않고 실행 계획을 만들 수 있는 Task, Stage
+type Plannable interface {
+	Plan() *v2.PlanNode
+}
+
+// PlanOf | v 가 Plannable 이면 실행 계획을 반환합니다.
+// 구조를 알 수 없는 Task, Stage 는 하위 노드가 없는 계획으로 표시됩니다.
+func PlanOf(v any) *v2.PlanNode {
+	if p, ok := v.(Plannable); ok {
+		return p.Plan()
+	}
+	switch v.(type) {
+	case Stage:
+		return &v2.PlanNode{Kind: PlanStage, Name: fmt.Sprintf("%T", v)}
+	default:
+		return &v2.PlanNode{Kind: PlanTask, Name: fmt.Sprintf("%T", v)}
+	}
+}
+
+func newTaskPlan(name string) *v2.PlanNode {
+	return &v2.PlanNode{Kind: PlanTask, Name: name}
+}
+
+// namedPlan | v 의 실행 계획 앞에 label 을 붙여 반환합니다. ex) "task[0] composite"
+func namedPlan(label string, v any) *v2.PlanNode {
+	plan := PlanOf(v)
+	plan.Name = fmt.Sprintf("%s %s", label, plan.Name)
+	return plan
+}
+
+// Plan | step 을 실행 순서대로 나열한 계획을 반환합니다.
+// FunctionType 은 타입 정보가 없으므로 요청, 응답 타입은 표시되지 않습니다.
+func (t *CompositeTask) Plan() *v2.PlanNode {
+	plan := newTaskPlan("composite")
+	for i, step := range t.Steps {
+		kind := v2.PlanFunction
+		if step.Converter {
+			kind = v2.PlanConverter
+		}
+		plan.Children = append(plan.Children, &v2.PlanNode{Kind: kind, Name: t.stepName(i)})
+	}
+	return plan
+}
+
+// Plan | 동시에 실행될 Task 들의 계획을 반환합니다.
+func (s *ConcurrentStage) Plan() *v2.PlanNode {
+	plan := &v2.PlanNode{Kind: PlanStage, Name: "concurrent", Parallel: true}
+	if s.Pool != nil {
+		plan.Name = fmt.Sprintf("concurrent (max %d)", s.Pool.Size())
+	}
+	for i, task := range s.Tasks {
+		plan.Children = append(plan.Children, namedPlan(fmt.Sprintf("task[%d]", i), task))
+	}
+	return plan
+}
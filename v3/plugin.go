@@ -0,0 +1,234 @@
+package v3
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	v2 "func_decorator/v2"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// PluginFunction | plugin 이 제공하는 function 과 metadata
+type PluginFunction struct {
+	Metadata FunctionMetadata
+	Fn       FunctionType
+}
+
+// Plugin | host 와 따로 배포되는 function 묶음
+type Plugin interface {
+	Name() string
+	Functions() []PluginFunction
+	// Close | plugin 이 사용하는 자원(프로세스 등)을 정리합니다.
+	Close() error
+}
+
+// PluginRegistration | registry 에 등록된 plugin, Unload 로 등록을 해제합니다.
+type PluginRegistration struct {
+	plugin     Plugin
+	names      []string
+	deregister func(name string)
+	once       sync.Once
+}
+
+// Plugin | 등록된 plugin 을 반환합니다.
+func (r *PluginRegistration) Plugin() Plugin {
+	return r.plugin
+}
+
+// Names | 등록된 function 의 이름(버전 포함)을 반환합니다.
+func (r *PluginRegistration) Names() []string {
+	return append([]string(nil), r.names...)
+}
+
+// Unload | 등록된 function 을 registry 에서 삭제하고 plugin 을 닫습니다.
+func (r *PluginRegistration) Unload() error {
+	var err error
+	r.once.Do(func() {
+		for _, name := range r.names {
+			r.deregister(name)
+		}
+		err = r.plugin.Close()
+	})
+	return err
+}
+
+// RegisterPlugin | plugin 의 function 을 metadata 와 함께 registry 에 등록합니다.
+// 하나라도 등록에 실패하면 앞서 등록한 function 을 삭제하고 에러를 반환합니다.
+func RegisterPlugin(registry FunctionRegistry, plugin Plugin) (*PluginRegistration, error) {
+	reg := &PluginRegistration{plugin: plugin, deregister: registry.DeregisterFunction}
+	for _, f := range plugin.Functions() {
+		if err := registry.RegisterFunctionWithMetadata(f.Metadata, f.Fn); err != nil {
+			reg.rollback()
+			return nil, fmt.Errorf("plugin '%s': %w", plugin.Name(), err)
+		}
+		reg.names = append(reg.names, v2.VersionedName(f.Metadata.Name, f.Metadata.Version))
+	}
+	return reg, nil
+}
+
+// RegisterPluginV2 | plugin 의 function 을 v2 registry 에 AnyFunction 으로 등록합니다.
+// metadata 에 InputType, OutputType 이 없으면 any 타입으로 등록됩니다.
+func RegisterPluginV2(registry v2.FunctionRegistry, plugin Plugin) (*PluginRegistration, error) {
+	reg := &PluginRegistration{plugin: plugin, deregister: registry.DeregisterFunction}
+	for _, f := range plugin.Functions() {
+		name := v2.VersionedName(f.Metadata.Name, f.Metadata.Version)
+		fn, err := v2.NewAnyFunction(typeOrAny(f.Metadata.InputType), typeOrAny(f.Metadata.OutputType), f.Fn)
+		if err == nil {
+			err = registry.RegisterFunction(name, fn)
+		}
+		if err != nil {
+			reg.rollback()
+			return nil, fmt.Errorf("plugin '%s': %w", plugin.Name(), err)
+		}
+		reg.names = append(reg.names, name)
+	}
+	return reg, nil
+}
+
+func (r *PluginRegistration) rollback() {
+	for _, name := range r.names {
+		r.deregister(name)
+	}
+	r.names = nil
+}
+
+func typeOrAny(t reflect.Type) reflect.Type {
+	if t == nil {
+		return v2.GetGenericType[any]()
+	}
+	return t
+}
+
+// plugin 프로세스와 주고받는 메시지 (한 줄에 JSON 하나)
+// host -> plugin : {"id":1,"method":"describe"}, {"id":2,"method":"call","function":"add","input":{...}}
+// plugin -> host : {"id":1,"functions":[...]}, {"id":2,"output":{...}} 또는 {"id":2,"error":"..."}
+// host 가 응답을 기다리다 취소하면 {"id":2,"method":"cancel"} 으로 같은 id 의 call 을 취소하며, cancel 에는 응답하지 않습니다.
+const (
+	pluginDescribe = "describe"
+	pluginCall     = "call"
+	pluginCancel   = "cancel"
+)
+
+type pluginRequest struct {
+	ID       uint64          `json:"id"`
+	Method   string          `json:"method"`
+	Function string          `json:"function,omitempty"`
+	Input    json.RawMessage `json:"input,omitempty"`
+}
+
+type pluginResponse struct {
+	ID        uint64          `json:"id"`
+	Functions []FunctionInfo  `json:"functions,omitempty"`
+	Output    json.RawMessage `json:"output,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// ServePlugin | r 로 들어오는 요청을 registry 의 function 으로 처리하고 w 로 응답합니다.
+// plugin 프로세스의 main 에서 ServePlugin(ctx, os.Stdin, os.Stdout, registry) 로 사용하며, r 이 닫히면 반환됩니다.
+// input 은 metadata 의 InputType 이 있으면 그 타입으로, 없으면 any 로 decode 합니다.
+// 각 call 은 cancel 요청을 받으면 취소되는 ctx 로 호출되며, function 의 패닉은 에러 응답으로 바뀝니다.
+func ServePlugin(ctx context.Context, r io.Reader, w io.Writer, registry FunctionRegistry) error {
+	var mu sync.Mutex
+	enc := json.NewEncoder(w)
+	write := func(res pluginResponse) {
+		mu.Lock()
+		defer mu.Unlock()
+		_ = enc.Encode(res)
+	}
+
+	var cancelMu sync.Mutex
+	cancels := make(map[uint64]context.CancelFunc) // 실행 중인 call 의 id -> 취소 func
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var req pluginRequest
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			write(pluginResponse{Error: fmt.Sprintf("invalid request: %v", err)})
+			continue
+		}
+		switch req.Method {
+		case pluginDescribe:
+			infos := make([]FunctionInfo, 0)
+			for _, metadata := range registry.List() {
+				infos = append(infos, metadata.Info())
+			}
+			write(pluginResponse{ID: req.ID, Functions: infos})
+		case pluginCall:
+			callCtx, cancel := context.WithCancel(ctx)
+			cancelMu.Lock()
+			cancels[req.ID] = cancel
+			cancelMu.Unlock()
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() {
+					cancelMu.Lock()
+					delete(cancels, req.ID)
+					cancelMu.Unlock()
+					cancel()
+				}()
+				output, err := CallJSONFunction(callCtx, registry, req.Function, req.Input)
+				if err != nil {
+					write(pluginResponse{ID: req.ID, Error: err.Error()})
+					return
+				}
+				write(pluginResponse{ID: req.ID, Output: output})
+			}()
+		case pluginCancel:
+			cancelMu.Lock()
+			if cancel, ok := cancels[req.ID]; ok {
+				cancel()
+			}
+			cancelMu.Unlock()
+		default:
+			write(pluginResponse{ID: req.ID, Error: fmt.Sprintf("unknown method '%s'", req.Method)})
+		}
+	}
+	return scanner.Err()
+}
+
+// CallJSONFunction | JSON input 을 decode 해서 name 의 function 을 호출하고, 결과를 JSON 으로 반환합니다.
+// plugin, remote, gRPC(grpctransport) 서버가 함께 사용하며, function 이 패닉이 나면 PanicError 를 반환합니다.
+// input 을 metadata 의 InputType 으로 decode 하지 못하면 ErrInvalidInput 을 감싼 에러를 반환합니다.
+func CallJSONFunction(ctx context.Context, registry FunctionRegistry, name string, input json.RawMessage) (output json.RawMessage, err error) {
+	metadata, err := registry.Describe(name)
+	if err != nil {
+		return nil, err
+	}
+	fn, err := registry.GetFunction(v2.VersionedName(metadata.Name, metadata.Version))
+	if err != nil {
+		return nil, err
+	}
+	req, err := DecodeJSON(input, metadata.InputType)
+	if err != nil {
+		return nil, fmt.Errorf("function '%s': %w: %v", name, ErrInvalidInput, err)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			output, err = nil, v2.RecoveredPanic(ctx, name, req, r)
+		}
+	}()
+	res, err := fn(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(res)
+}
+
+// DecodeJSON | data 를 typ 으로 decode 합니다. typ 이 nil 이면 any 로 decode 합니다.
+func DecodeJSON(data json.RawMessage, typ reflect.Type) (any, error) {
+	typ = typeOrAny(typ)
+	value := reflect.New(typ)
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, value.Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return value.Elem().Interface(), nil
+}
@@ -0,0 +1,48 @@
+//go:build linux && cgo
+
+package v3
+
+import (
+	"fmt"
+	"plugin"
+)
+
+// GoPluginSymbol | Go plugin(.so) 이 export 해야 하는 심볼 이름
+// plugin 은 `func Functions() []v3.PluginFunction` 을 선언하고 -buildmode=plugin 으로 빌드합니다.
+const GoPluginSymbol = "Functions"
+
+// goPlugin | -buildmode=plugin 으로 빌드된 Go plugin
+type goPlugin struct {
+	name      string
+	functions []PluginFunction
+}
+
+// OpenGoPlugin | path 의 Go plugin 을 열어 Plugin 을 만듭니다.
+// Go plugin 은 프로세스에서 내릴 수 없으므로, Unload 는 registry 에서 function 을 삭제하는 것만 합니다.
+func OpenGoPlugin(path string) (Plugin, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("plugin '%s': %w", path, err)
+	}
+	sym, err := p.Lookup(GoPluginSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("plugin '%s': %w", path, err)
+	}
+	functions, ok := sym.(func() []PluginFunction)
+	if !ok {
+		return nil, fmt.Errorf("plugin '%s': symbol %s has type %T, want func() []v3.PluginFunction", path, GoPluginSymbol, sym)
+	}
+	return &goPlugin{name: path, functions: functions()}, nil
+}
+
+func (p *goPlugin) Name() string {
+	return p.name
+}
+
+func (p *goPlugin) Functions() []PluginFunction {
+	return append([]PluginFunction(nil), p.functions...)
+}
+
+func (p *goPlugin) Close() error {
+	return nil
+}
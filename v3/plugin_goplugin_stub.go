@@ -0,0 +1,13 @@
+//go:build !linux || !cgo
+
+package v3
+
+import "fmt"
+
+// GoPluginSymbol | Go plugin(.so) 이 export 해야 하는 심볼 이름
+const GoPluginSymbol = "Functions"
+
+// OpenGoPlugin | Go plugin 은 linux 에서 cgo 가 켜져 있을 때만 지원됩니다. StartProcessPlugin 을 사용하세요.
+func OpenGoPlugin(path string) (Plugin, error) {
+	return nil, fmt.Errorf("plugin '%s': go plugins are not supported on this platform, use StartProcessPlugin", path)
+}
@@ -0,0 +1,184 @@
+package v3
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	v2 "func_decorator/v2"
+	"io"
+	"os/exec"
+	"sync"
+)
+
+// errPluginClosed | 닫힌 plugin 을 호출했을 때의 에러
+var errPluginClosed = errors.New("plugin is closed")
+
+// processPlugin | stdin, stdout 으로 ServePlugin 을 실행하는 프로세스와 통신하는 Plugin
+type processPlugin struct {
+	name      string
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	functions []PluginFunction
+	done      chan struct{} // stdout 을 모두 읽으면 닫힘
+	closeOnce sync.Once
+	closeErr  error
+
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[uint64]chan pluginResponse
+	closed  bool
+}
+
+// StartProcessPlugin | cmd 를 실행하고 제공하는 function 목록을 받아 Plugin 을 만듭니다.
+// cmd 는 ServePlugin 으로 stdin, stdout 을 처리해야 하며 Stdin, Stdout 은 지정하지 않아야 합니다.
+// 프로세스에서 받은 출력은 타입 정보가 없으므로 any (map[string]any, float64 등) 로 decode 됩니다.
+func StartProcessPlugin(name string, cmd *exec.Cmd) (Plugin, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("plugin '%s': %w", name, err)
+	}
+
+	p := &processPlugin{
+		name:    name,
+		cmd:     cmd,
+		stdin:   stdin,
+		done:    make(chan struct{}),
+		pending: make(map[uint64]chan pluginResponse),
+	}
+	go p.read(stdout)
+
+	res, err := p.request(context.Background(), pluginRequest{Method: pluginDescribe})
+	if err != nil {
+		_ = p.Close()
+		return nil, fmt.Errorf("plugin '%s': failed to describe: %w", name, err)
+	}
+	for _, info := range res.Functions {
+		p.functions = append(p.functions, PluginFunction{
+			Metadata: info.Metadata(),
+			Fn:       p.function(v2.VersionedName(info.Name, info.Version)),
+		})
+	}
+	return p, nil
+}
+
+func (p *processPlugin) Name() string {
+	return p.name
+}
+
+func (p *processPlugin) Functions() []PluginFunction {
+	return append([]PluginFunction(nil), p.functions...)
+}
+
+// Close | stdin 을 닫아 프로세스를 종료시키고 종료될 때까지 기다립니다.
+func (p *processPlugin) Close() error {
+	p.closeOnce.Do(func() {
+		p.mu.Lock()
+		p.closed = true
+		p.mu.Unlock()
+
+		_ = p.stdin.Close()
+		<-p.done
+		p.closeErr = p.cmd.Wait()
+	})
+	return p.closeErr
+}
+
+func (p *processPlugin) function(name string) FunctionType {
+	return func(ctx context.Context, req any) (any, error) {
+		input, err := json.Marshal(req)
+		if err != nil {
+			return nil, fmt.Errorf("plugin '%s' function '%s': failed to encode input: %w", p.name, name, err)
+		}
+		res, err := p.request(ctx, pluginRequest{Method: pluginCall, Function: name, Input: input})
+		if err != nil {
+			return nil, fmt.Errorf("plugin '%s' function '%s': %w", p.name, name, err)
+		}
+		output, err := DecodeJSON(res.Output, nil)
+		if err != nil {
+			return nil, fmt.Errorf("plugin '%s' function '%s': failed to decode output: %w", p.name, name, err)
+		}
+		return output, nil
+	}
+}
+
+// request | 요청을 보내고 같은 id 의 응답을 기다립니다.
+func (p *processPlugin) request(ctx context.Context, req pluginRequest) (pluginResponse, error) {
+	ch := make(chan pluginResponse, 1)
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return pluginResponse{}, errPluginClosed
+	}
+	p.nextID++
+	req.ID = p.nextID
+	p.pending[req.ID] = ch
+	data, err := json.Marshal(req)
+	if err == nil {
+		_, err = p.stdin.Write(append(data, '\n'))
+	}
+	if err != nil {
+		delete(p.pending, req.ID)
+		p.mu.Unlock()
+		return pluginResponse{}, err
+	}
+	p.mu.Unlock()
+
+	select {
+	case res, ok := <-ch:
+		if !ok {
+			return pluginResponse{}, errPluginClosed
+		}
+		if res.Error != "" {
+			return res, errors.New(res.Error)
+		}
+		return res, nil
+	case <-ctx.Done():
+		p.mu.Lock()
+		delete(p.pending, req.ID)
+		if req.Method == pluginCall && !p.closed {
+			// 프로세스에서 실행 중인 call 도 취소되도록 알림 (실패해도 응답은 버려지므로 무시)
+			if data, err := json.Marshal(pluginRequest{ID: req.ID, Method: pluginCancel}); err == nil {
+				_, _ = p.stdin.Write(append(data, '\n'))
+			}
+		}
+		p.mu.Unlock()
+		return pluginResponse{}, ctx.Err()
+	}
+}
+
+// read | 프로세스의 응답을 요청한 호출에 전달합니다. 프로세스가 종료되면 대기 중인 호출은 에러를 받습니다.
+func (p *processPlugin) read(stdout io.Reader) {
+	defer close(p.done)
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var res pluginResponse
+		if err := json.Unmarshal(scanner.Bytes(), &res); err != nil {
+			continue
+		}
+		p.mu.Lock()
+		ch, ok := p.pending[res.ID]
+		delete(p.pending, res.ID)
+		p.mu.Unlock()
+		if ok {
+			ch <- res
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	for id, ch := range p.pending {
+		close(ch)
+		delete(p.pending, id)
+	}
+}
@@ -0,0 +1,137 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	v2 "func_decorator/v2"
+	"os"
+	"os/exec"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestPluginHelperProcess | plugin 프로세스 역할을 하는 테스트 바이너리, 직접 실행되지 않음
+func TestPluginHelperProcess(t *testing.T) {
+	if os.Getenv("FD_PLUGIN_HELPER") != "1" {
+		t.Skip("helper process")
+	}
+	registry := newTransportTestRegistry()
+	// wait 는 취소될 때까지 기다리며, canceled 는 취소된 wait 의 수를 반환함
+	var canceled int32
+	registry.RegisterFunction("wait", func(ctx context.Context, req any) (any, error) {
+		<-ctx.Done()
+		atomic.AddInt32(&canceled, 1)
+		return nil, ctx.Err()
+	})
+	registry.RegisterFunction("canceled", func(ctx context.Context, req any) (any, error) {
+		return atomic.LoadInt32(&canceled), nil
+	})
+	if err := ServePlugin(context.Background(), os.Stdin, os.Stdout, registry); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func startHelperPlugin(t *testing.T) Plugin {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestPluginHelperProcess$")
+	cmd.Env = append(os.Environ(), "FD_PLUGIN_HELPER=1")
+	p, err := StartProcessPlugin("math", cmd)
+	if err != nil {
+		t.Fatalf("StartProcessPlugin() error = %v", err)
+	}
+	return p
+}
+
+func TestProcessPlugin(t *testing.T) {
+	registry := NewSimpleFunctionRegistry()
+	reg, err := RegisterPlugin(registry, startHelperPlugin(t))
+	if err != nil {
+		t.Fatalf("RegisterPlugin() error = %v", err)
+	}
+
+	metadata, err := registry.Describe("add")
+	if err != nil || metadata.Version != "1.0.0" || !metadata.HasTag("math") {
+		t.Fatalf("Describe() = %+v, %v", metadata, err)
+	}
+	add, _ := registry.GetFunction("add")
+	res, err := add(context.Background(), transportInput{A: 1, B: 2})
+	if want := map[string]any{"sum": float64(3)}; err != nil || !reflect.DeepEqual(res, want) {
+		t.Errorf("add() = %v, %v", res, err)
+	}
+	fail, _ := registry.GetFunction("fail")
+	if _, err := fail(context.Background(), nil); err == nil || err.Error() != "plugin 'math' function 'fail': boom" {
+		t.Errorf("fail() error = %v", err)
+	}
+
+	explode, _ := registry.GetFunction("explode")
+	if _, err := explode(context.Background(), nil); err == nil || err.Error() != "plugin 'math' function 'explode': function 'explode' panicked: kaboom" {
+		t.Errorf("explode() error = %v", err)
+	}
+
+	// host 에서 취소하면 plugin 프로세스의 call 도 취소됨
+	wait, _ := registry.GetFunction("wait")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := wait(ctx, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wait() error = %v, want DeadlineExceeded", err)
+	}
+	canceled, _ := registry.GetFunction("canceled")
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(5 * time.Millisecond) {
+		if n, err := canceled(context.Background(), nil); err == nil && n == float64(1) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("wait should be canceled in the plugin process")
+		}
+	}
+
+	if err := reg.Unload(); err != nil {
+		t.Fatalf("Unload() error = %v", err)
+	}
+	if _, err := registry.GetFunction("add"); !errors.Is(err, ErrFunctionNotFound) {
+		t.Errorf("GetFunction() after Unload error = %v", err)
+	}
+	if _, err := add(context.Background(), transportInput{}); !errors.Is(err, errPluginClosed) {
+		t.Errorf("add() after Unload error = %v", err)
+	}
+}
+
+func TestRegisterPluginV2(t *testing.T) {
+	registry := v2.NewFunctionRegistry()
+	existing, _ := v2.NewAnyFunction(v2.GetGenericType[any](), v2.GetGenericType[any](), func(ctx context.Context, req any) (any, error) {
+		return req, nil
+	})
+	registry.RegisterFunction("fail", existing)
+	p := startHelperPlugin(t)
+	defer p.Close()
+
+	// 이미 등록된 이름이 있으면 앞서 등록한 function 도 되돌림
+	if _, err := RegisterPluginV2(registry, p); !errors.Is(err, ErrDuplicateFunction) {
+		t.Fatalf("RegisterPluginV2() error = %v", err)
+	}
+	if _, ok := registry.GetFunctionNode("add@1.0.0"); ok {
+		t.Errorf("add@1.0.0 should be rolled back")
+	}
+
+	registry.DeregisterFunction("fail")
+	reg, err := RegisterPluginV2(registry, p)
+	if err != nil {
+		t.Fatalf("RegisterPluginV2() error = %v", err)
+	}
+	node, ok := registry.GetFunctionNode("add")
+	if !ok {
+		t.Fatalf("add is not registered")
+	}
+	res, err := node.Function.Call(context.Background(), map[string]any{"a": 2, "b": 5})
+	if want := map[string]any{"sum": float64(7)}; err != nil || !reflect.DeepEqual(res, want) {
+		t.Errorf("add() = %v, %v", res, err)
+	}
+	if err := reg.Unload(); err != nil {
+		t.Fatalf("Unload() error = %v", err)
+	}
+	if ids := registry.FunctionIDs(); len(ids) != 0 {
+		t.Errorf("FunctionIDs() after Unload = %v", ids)
+	}
+}
@@ -2,35 +2,286 @@ package v3
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	v2 "func_decorator/v2"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 )
 
 // FunctionType Function Registry 용 FunctionType을 정의합니다.
 type FunctionType func(context.Context, any) (any, error)
 
+// FunctionMetadata | 등록된 function 이 무엇을 하고, 무엇을 주고받는지 설명하는 정보
+// InputType, OutputType 은 알 수 없으면 nil 입니다.
+type FunctionMetadata struct {
+	Name        string
+	Description string
+	InputType   reflect.Type
+	OutputType  reflect.Type
+	Version     string
+	Tags        []string
+	Owner       string
+	Idempotent  bool // 같은 input 으로 여러 번 호출해도 결과가 같고 부작용이 없는지
+}
+
+// HasTag | tag 가 Tags 에 있는지 확인합니다.
+func (m FunctionMetadata) HasTag(tag string) bool {
+	for _, t := range m.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// String | "add (v3.AddInput -> v3.AddOutput) : 두 수를 더합니다." 형태로 반환합니다.
+func (m FunctionMetadata) String() string {
+	var sb strings.Builder
+	sb.WriteString(m.Name)
+	if m.Version != "" {
+		sb.WriteString("@" + m.Version)
+	}
+	sb.WriteString(fmt.Sprintf(" (%s -> %s)", metadataTypeName(m.InputType), metadataTypeName(m.OutputType)))
+	if m.Description != "" {
+		sb.WriteString(" : " + m.Description)
+	}
+	return sb.String()
+}
+
+// FunctionInfo | JSON 으로 주고받기 위한 FunctionMetadata, 타입은 이름으로만 전달됩니다.
+type FunctionInfo struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	InputType   string   `json:"inputType,omitempty"`
+	OutputType  string   `json:"outputType,omitempty"`
+	Version     string   `json:"version,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Owner       string   `json:"owner,omitempty"`
+	Idempotent  bool     `json:"idempotent,omitempty"`
+}
+
+// Info | JSON 으로 주고받기 위한 FunctionInfo 로 변환합니다.
+func (m FunctionMetadata) Info() FunctionInfo {
+	info := FunctionInfo{
+		Name:        m.Name,
+		Description: m.Description,
+		Version:     m.Version,
+		Tags:        append([]string(nil), m.Tags...),
+		Owner:       m.Owner,
+		Idempotent:  m.Idempotent,
+	}
+	if m.InputType != nil {
+		info.InputType = m.InputType.String()
+	}
+	if m.OutputType != nil {
+		info.OutputType = m.OutputType.String()
+	}
+	return info
+}
+
+// Metadata | FunctionMetadata 로 변환합니다. InputType, OutputType 은 알 수 없으므로 nil 입니다.
+func (i FunctionInfo) Metadata() FunctionMetadata {
+	return FunctionMetadata{
+		Name:        i.Name,
+		Description: i.Description,
+		Version:     i.Version,
+		Tags:        append([]string(nil), i.Tags...),
+		Owner:       i.Owner,
+		Idempotent:  i.Idempotent,
+	}
+}
+
+func metadataTypeName(t reflect.Type) string {
+	if t == nil {
+		return "?"
+	}
+	return t.String()
+}
+
+// FunctionRegistry | 이름으로 function 을 등록하고 조회하는 registry
+// 이름에 "add@1.2.0" 처럼 버전을 붙여 등록할 수 있으며, 조회 시 "add" 는 버전 없이 등록된 function 이 없다면 가장 높은 버전을,
+// "add@^1.0.0" 처럼 제약을 주면 제약을 만족하는 가장 높은 버전을 찾습니다. (v2.ParseVersionConstraint 참고)
 type FunctionRegistry interface {
-	RegisterFunction(name string, fn FunctionType)
+	// RegisterFunction | 같은 이름(과 버전)이 이미 등록되어 있으면 에러를 반환합니다.
+	RegisterFunction(name string, fn FunctionType) error
+	// RegisterFunctionWithMetadata | metadata.Name, metadata.Version 으로 function 과 metadata 를 함께 등록합니다.
+	RegisterFunctionWithMetadata(metadata FunctionMetadata, fn FunctionType) error
+	// ReplaceFunction | 이미 등록된 function 을 교체합니다. 등록되어 있지 않으면 FunctionNotFoundError 를 반환합니다.
+	ReplaceFunction(name string, fn FunctionType) error
+	ReplaceFunctionWithMetadata(metadata FunctionMetadata, fn FunctionType) error
 	GetFunction(name string) (FunctionType, error)
+	// DeregisterFunction | name 으로 등록된 function 을 삭제합니다. 버전이 있다면 "add@1.2.0" 처럼 지정합니다.
+	DeregisterFunction(name string)
+	// Versions | name 으로 등록된 버전을 낮은 순으로 반환합니다.
+	Versions(name string) []string
+	// List | 등록된 function 의 metadata 를 이름, 버전 순으로 반환합니다.
+	List() []FunctionMetadata
+	// FindByTag | tag 가 붙은 function 의 metadata 를 이름, 버전 순으로 반환합니다.
+	FindByTag(tag string) []FunctionMetadata
+	// Describe | name 으로 등록된 function 의 metadata 를 반환합니다.
+	Describe(name string) (FunctionMetadata, error)
+}
+
+type registeredFunction struct {
+	fn       FunctionType
+	metadata FunctionMetadata
 }
 
 type SimpleFunctionRegistry struct {
-	functions map[string]FunctionType
+	mu        sync.RWMutex
+	functions map[string]registeredFunction
 }
 
 func NewSimpleFunctionRegistry() FunctionRegistry {
 	return &SimpleFunctionRegistry{
-		functions: make(map[string]FunctionType),
+		functions: make(map[string]registeredFunction),
+	}
+}
+
+func (r *SimpleFunctionRegistry) RegisterFunction(name string, fn FunctionType) error {
+	return r.RegisterFunctionWithMetadata(FunctionMetadata{Name: name}, fn)
+}
+
+func (r *SimpleFunctionRegistry) RegisterFunctionWithMetadata(metadata FunctionMetadata, fn FunctionType) error {
+	return r.register(metadata, fn, false)
+}
+
+func (r *SimpleFunctionRegistry) ReplaceFunction(name string, fn FunctionType) error {
+	return r.ReplaceFunctionWithMetadata(FunctionMetadata{Name: name}, fn)
+}
+
+func (r *SimpleFunctionRegistry) ReplaceFunctionWithMetadata(metadata FunctionMetadata, fn FunctionType) error {
+	return r.register(metadata, fn, true)
+}
+
+func (r *SimpleFunctionRegistry) register(metadata FunctionMetadata, fn FunctionType, replace bool) error {
+	if fn == nil {
+		return fmt.Errorf("function '%s' is nil", metadata.Name)
+	}
+	name, version := v2.SplitVersionedName(metadata.Name)
+	if version != "" && metadata.Version != "" && version != metadata.Version {
+		return fmt.Errorf("function '%s' has a different version in metadata (%s)", metadata.Name, metadata.Version)
+	}
+	if version == "" {
+		version = metadata.Version
+	}
+	key, err := v2.NormalizeVersionedName(v2.VersionedName(name, version))
+	if err != nil {
+		return err
+	}
+	metadata.Name, metadata.Version = v2.SplitVersionedName(key)
+	metadata.Tags = append([]string(nil), metadata.Tags...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, exists := r.functions[key]
+	if exists && !replace {
+		return &v2.DuplicateFunctionError{Name: key}
 	}
+	if !exists && replace {
+		return &v2.FunctionNotFoundError{Name: key}
+	}
+	r.functions[key] = registeredFunction{fn: fn, metadata: metadata}
+	return nil
+}
+
+// lookup | name 을 그대로, 또는 버전 제약으로 찾습니다. r.mu 를 잡은 상태에서 호출해야 합니다.
+func (r *SimpleFunctionRegistry) lookup(name string) (registeredFunction, bool) {
+	if f, ok := r.functions[name]; ok {
+		return f, true
+	}
+	base, constraint := v2.SplitVersionedName(name)
+	version, err := v2.ResolveVersion(r.versions(base), constraint)
+	if err != nil {
+		return registeredFunction{}, false
+	}
+	f, ok := r.functions[v2.VersionedName(base, version)]
+	return f, ok
 }
 
-func (r *SimpleFunctionRegistry) RegisterFunction(name string, fn FunctionType) {
-	r.functions[name] = fn
+func (r *SimpleFunctionRegistry) versions(name string) []string {
+	versions := make([]string, 0)
+	for _, f := range r.functions {
+		if f.metadata.Name == name && f.metadata.Version != "" {
+			versions = append(versions, f.metadata.Version)
+		}
+	}
+	return versions
 }
 
 func (r *SimpleFunctionRegistry) GetFunction(name string) (FunctionType, error) {
-	fn, ok := r.functions[name]
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	f, ok := r.lookup(name)
+	if !ok {
+		return nil, &v2.FunctionNotFoundError{Name: name}
+	}
+	return f.fn, nil
+}
+
+func (r *SimpleFunctionRegistry) DeregisterFunction(name string) {
+	if normalized, err := v2.NormalizeVersionedName(name); err == nil {
+		name = normalized
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.functions, name)
+}
+
+func (r *SimpleFunctionRegistry) Versions(name string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	versions := r.versions(name)
+	sort.Slice(versions, func(i, j int) bool {
+		vi, _ := v2.ParseVersion(versions[i])
+		vj, _ := v2.ParseVersion(versions[j])
+		return vi.Compare(vj) < 0
+	})
+	return versions
+}
+
+func (r *SimpleFunctionRegistry) List() []FunctionMetadata {
+	return r.filter(func(FunctionMetadata) bool { return true })
+}
+
+func (r *SimpleFunctionRegistry) FindByTag(tag string) []FunctionMetadata {
+	return r.filter(func(m FunctionMetadata) bool { return m.HasTag(tag) })
+}
+
+func (r *SimpleFunctionRegistry) Describe(name string) (FunctionMetadata, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	f, ok := r.lookup(name)
 	if !ok {
-		return nil, errors.New("function not found")
+		return FunctionMetadata{}, &v2.FunctionNotFoundError{Name: name}
+	}
+	return copyMetadata(f.metadata), nil
+}
+
+// filter | match 를 만족하는 metadata 의 복사본을 이름 순으로 반환합니다.
+func (r *SimpleFunctionRegistry) filter(match func(FunctionMetadata) bool) []FunctionMetadata {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	list := make([]FunctionMetadata, 0, len(r.functions))
+	for _, f := range r.functions {
+		if match(f.metadata) {
+			list = append(list, copyMetadata(f.metadata))
+		}
 	}
-	return fn, nil
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		vi, _ := v2.ParseVersion(list[i].Version)
+		vj, _ := v2.ParseVersion(list[j].Version)
+		return vi.Compare(vj) < 0
+	})
+	return list
+}
+
+func copyMetadata(m FunctionMetadata) FunctionMetadata {
+	m.Tags = append([]string(nil), m.Tags...)
+	return m
 }
@@ -0,0 +1,144 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	v2 "func_decorator/v2"
+	"reflect"
+	"testing"
+)
+
+func TestFunctionRegistryMetadata(t *testing.T) {
+	registry := NewSimpleFunctionRegistry()
+	noop := func(ctx context.Context, a any) (any, error) { return a, nil }
+	registry.RegisterFunctionWithMetadata(FunctionMetadata{
+		Name:        "sum",
+		Description: "A + B",
+		InputType:   v2.GetGenericType[sumInput](),
+		OutputType:  v2.GetGenericType[int](),
+		Version:     "1.0.0",
+		Tags:        []string{"math"},
+		Owner:       "calc",
+		Idempotent:  true,
+	}, noop)
+	registry.RegisterFunctionWithMetadata(FunctionMetadata{Name: "abs", Tags: []string{"math"}}, noop)
+	registry.RegisterFunction("log", noop)
+
+	names := func(list []FunctionMetadata) []string {
+		out := make([]string, 0, len(list))
+		for _, m := range list {
+			out = append(out, m.Name)
+		}
+		return out
+	}
+	if got := names(registry.List()); !reflect.DeepEqual(got, []string{"abs", "log", "sum"}) {
+		t.Errorf("List() = %v", got)
+	}
+	if got := names(registry.FindByTag("math")); !reflect.DeepEqual(got, []string{"abs", "sum"}) {
+		t.Errorf("FindByTag() = %v", got)
+	}
+
+	metadata, err := registry.Describe("sum")
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	if metadata.String() != "sum@1.0.0 (v3.sumInput -> int) : A + B" || !metadata.Idempotent || metadata.Owner != "calc" {
+		t.Errorf("Describe() = %+v", metadata)
+	}
+	// 반환된 metadata 를 수정해도 registry 에는 영향이 없어야 함
+	metadata.Tags[0] = "changed"
+	if len(registry.FindByTag("math")) != 2 {
+		t.Error("Describe() should return a copy of metadata")
+	}
+
+	if _, err := registry.Describe("missing"); err == nil {
+		t.Error("Describe() of missing function should fail")
+	}
+}
+
+func TestFunctionRegistryVersions(t *testing.T) {
+	registry := NewSimpleFunctionRegistry()
+	constant := func(v int) FunctionType {
+		return func(ctx context.Context, a any) (any, error) { return v, nil }
+	}
+
+	if err := registry.RegisterFunction("add", constant(0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.RegisterFunction("add", constant(0)); err == nil {
+		t.Error("RegisterFunction() with duplicated name should fail")
+	}
+	if err := registry.RegisterFunction("add@1.0.0", constant(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.RegisterFunctionWithMetadata(FunctionMetadata{Name: "add", Version: "1.2"}, constant(12)); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.RegisterFunction("add@2.0.0", constant(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.RegisterFunctionWithMetadata(FunctionMetadata{Name: "add@3.0.0", Version: "2.0.0"}, constant(3)); err == nil {
+		t.Error("RegisterFunctionWithMetadata() with conflicting version should fail")
+	}
+	if got := registry.Versions("add"); !reflect.DeepEqual(got, []string{"1.0.0", "1.2.0", "2.0.0"}) {
+		t.Errorf("Versions() = %v", got)
+	}
+
+	tests := map[string]int{
+		"add":        0, // 버전 없이 등록된 function 이 그대로 조회됨
+		"add@1.2.0":  12,
+		"add@^1.0.0": 12,
+		"add@~1.0.0": 1,
+		"add@>=1.5":  2,
+	}
+	for name, want := range tests {
+		fn, err := registry.GetFunction(name)
+		if err != nil {
+			t.Errorf("GetFunction(%q) error = %v", name, err)
+			continue
+		}
+		if got, _ := fn(context.Background(), nil); got != want {
+			t.Errorf("GetFunction(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	if err := registry.ReplaceFunction("add@2.0.0", constant(20)); err != nil {
+		t.Fatal(err)
+	}
+	fn, _ := registry.GetFunction("add@2")
+	if got, _ := fn(context.Background(), nil); got != 20 {
+		t.Errorf("ReplaceFunction() did not replace, got %v", got)
+	}
+	if err := registry.ReplaceFunction("add@3.0.0", constant(30)); !errors.Is(err, ErrFunctionNotFound) {
+		t.Errorf("ReplaceFunction() of missing version error = %v, want ErrFunctionNotFound", err)
+	}
+	if _, err := registry.GetFunction("add@^5"); err == nil {
+		t.Error("GetFunction() should fail when no version matches")
+	}
+}
+
+func TestFunctionRegistryErrors(t *testing.T) {
+	registry := NewSimpleFunctionRegistry()
+	noop := func(ctx context.Context, a any) (any, error) { return a, nil }
+	registry.RegisterFunction("add@1.0.0", noop)
+
+	_, err := registry.GetFunction("sub")
+	var notFound *FunctionNotFoundError
+	if !errors.Is(err, ErrFunctionNotFound) || !errors.As(err, &notFound) || notFound.Name != "sub" {
+		t.Errorf("GetFunction() error = %v, want FunctionNotFoundError(sub)", err)
+	}
+
+	err = registry.RegisterFunction("add@1.0", noop)
+	var duplicate *DuplicateFunctionError
+	if !errors.Is(err, ErrDuplicateFunction) || !errors.As(err, &duplicate) || duplicate.Name != "add@1.0.0" {
+		t.Errorf("RegisterFunction() error = %v, want DuplicateFunctionError(add@1.0.0)", err)
+	}
+
+	sum := Typed("sum", func(ctx context.Context, in sumInput) (int, error) { return in.A + in.B, nil })
+	exclaim := Typed("exclaim", func(ctx context.Context, in string) (string, error) { return in, nil })
+	_, err = BuildTypedTask[sumInput, string](sum, exclaim)
+	var mismatch *TypeMismatchError
+	if !errors.Is(err, ErrTypeMismatch) || !errors.As(err, &mismatch) || mismatch.From != "sum" || mismatch.To != "exclaim" {
+		t.Errorf("BuildTypedTask() error = %v, want TypeMismatchError(sum -> exclaim)", err)
+	}
+}
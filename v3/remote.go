@@ -0,0 +1,240 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	v2 "func_decorator/v2"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// RemoteHandler 의 경로
+// GET  /functions        : 등록된 function 의 FunctionInfo 목록
+// GET  /functions/{name} : name 의 FunctionInfo
+// POST /functions/{name} : body 의 JSON 을 input 으로 name 을 호출하고 remoteResponse 를 반환
+const remoteFunctionsPath = "/functions"
+
+// remoteResponse | 호출 결과, 실패하면 Error 에 메시지가 담김
+type remoteResponse struct {
+	Output json.RawMessage `json:"output,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// RemoteHandler | registry 의 function 을 HTTP/JSON 으로 호출할 수 있게 하는 http.Handler
+// 다른 경로에 붙일 때는 http.StripPrefix 를 사용합니다.
+type RemoteHandler struct {
+	registry FunctionRegistry
+}
+
+// NewRemoteHandler | registry 의 function 을 제공하는 RemoteHandler 를 만듭니다.
+// input 은 metadata 의 InputType 이 있으면 그 타입으로, 없으면 any 로 decode 합니다.
+func NewRemoteHandler(registry FunctionRegistry) *RemoteHandler {
+	return &RemoteHandler{registry: registry}
+}
+
+func (h *RemoteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == remoteFunctionsPath || r.URL.Path == remoteFunctionsPath+"/" {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		infos := make([]FunctionInfo, 0)
+		for _, metadata := range h.registry.List() {
+			infos = append(infos, metadata.Info())
+		}
+		writeJSON(w, http.StatusOK, infos)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, remoteFunctionsPath+"/")
+	if name == r.URL.Path || name == "" {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		metadata, err := h.registry.Describe(name)
+		if err != nil {
+			writeError(w, remoteStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusOK, metadata.Info())
+	case http.MethodPost:
+		var input json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("function '%s': %w: %v", name, ErrInvalidInput, err))
+			return
+		}
+		output, err := CallJSONFunction(r.Context(), h.registry, name, input)
+		if err != nil {
+			writeError(w, remoteStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusOK, remoteResponse{Output: output})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+	}
+}
+
+func remoteStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrFunctionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidInput), errors.Is(err, ErrTypeMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, remoteResponse{Error: err.Error()})
+}
+
+// RemoteError | 원격 호출이 실패했을 때의 에러
+// gRPC client 와 같은 분류로 errors.Is 를 쓸 수 있도록 StatusCode 를 sentinel 에러로 풀어줍니다.
+// 404 : ErrFunctionNotFound, 400 : ErrInvalidInput, 504 : context.DeadlineExceeded
+type RemoteError struct {
+	Name       string
+	StatusCode int
+	Message    string
+}
+
+func (e *RemoteError) Error() string {
+	return fmt.Sprintf("remote function '%s' failed (%d): %s", e.Name, e.StatusCode, e.Message)
+}
+
+func (e *RemoteError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return ErrFunctionNotFound
+	case http.StatusBadRequest:
+		return ErrInvalidInput
+	case http.StatusGatewayTimeout:
+		return context.DeadlineExceeded
+	default:
+		return nil
+	}
+}
+
+// RemoteClient | RemoteHandler 로 제공되는 function 을 호출하는 client
+type RemoteClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewRemoteClient | baseURL 의 RemoteHandler 를 호출하는 client 를 만듭니다. client 가 nil 이면 http.DefaultClient 를 사용합니다.
+func NewRemoteClient(baseURL string, client *http.Client) *RemoteClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &RemoteClient{baseURL: strings.TrimSuffix(baseURL, "/"), client: client}
+}
+
+// List | 원격 registry 에 등록된 function 의 FunctionInfo 목록을 반환합니다.
+func (c *RemoteClient) List(ctx context.Context) ([]FunctionInfo, error) {
+	infos := make([]FunctionInfo, 0)
+	if err := c.do(ctx, http.MethodGet, "", nil, &infos); err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+// Describe | 원격 registry 의 name 에 대한 FunctionInfo 를 반환합니다.
+func (c *RemoteClient) Describe(ctx context.Context, name string) (FunctionInfo, error) {
+	var info FunctionInfo
+	err := c.do(ctx, http.MethodGet, name, nil, &info)
+	return info, err
+}
+
+// Function | 원격의 name 을 호출하는 FunctionType 을 반환합니다.
+// 응답은 outputType 으로 decode 하며, outputType 이 nil 이면 any (map[string]any, float64 등) 로 decode 합니다.
+func (c *RemoteClient) Function(name string, outputType reflect.Type) FunctionType {
+	return func(ctx context.Context, req any) (any, error) {
+		input, err := json.Marshal(req)
+		if err != nil {
+			return nil, fmt.Errorf("remote function '%s': failed to encode input: %w", name, err)
+		}
+		var res remoteResponse
+		if err := c.do(ctx, http.MethodPost, name, input, &res); err != nil {
+			return nil, err
+		}
+		output, err := DecodeJSON(res.Output, outputType)
+		if err != nil {
+			return nil, fmt.Errorf("remote function '%s': failed to decode output: %w", name, err)
+		}
+		return output, nil
+	}
+}
+
+// RemoteFunction | 원격의 name 을 호출하고 응답을 Res 로 decode 하는 FunctionType 을 반환합니다.
+func RemoteFunction[Res any](c *RemoteClient, name string) FunctionType {
+	return c.Function(name, v2.GetGenericType[Res]())
+}
+
+// RemoteAnyFunction | 원격의 name 을 호출하는 v2.AnyFunction 을 반환합니다.
+func RemoteAnyFunction[Req, Res any](c *RemoteClient, name string) (v2.AnyFunction, error) {
+	return v2.NewAnyFunction(v2.GetGenericType[Req](), v2.GetGenericType[Res](), RemoteFunction[Res](c, name))
+}
+
+// RegisterRemote | 원격 registry 의 function 을 metadata 와 함께 registry 에 등록합니다.
+// 타입 정보는 전달되지 않으므로 응답은 any 로 decode 됩니다. 타입이 필요하면 RemoteFunction 으로 직접 등록합니다.
+func (c *RemoteClient) RegisterRemote(ctx context.Context, registry FunctionRegistry) error {
+	infos, err := c.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		name := v2.VersionedName(info.Name, info.Version)
+		if err := registry.RegisterFunctionWithMetadata(info.Metadata(), c.Function(name, nil)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// do | 요청을 보내고 응답을 out 에 decode 합니다. 200 이 아니면 RemoteError 를 반환합니다.
+func (c *RemoteClient) do(ctx context.Context, method, name string, body []byte, out any) error {
+	endpoint := c.baseURL + remoteFunctionsPath
+	if name != "" {
+		endpoint += "/" + url.PathEscape(name)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("remote function '%s': %w", name, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		var failed remoteResponse
+		if err := json.NewDecoder(res.Body).Decode(&failed); err != nil || failed.Error == "" {
+			failed.Error = http.StatusText(res.StatusCode)
+		}
+		return &RemoteError{Name: name, StatusCode: res.StatusCode, Message: failed.Error}
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("remote function '%s': failed to decode response: %w", name, err)
+	}
+	return nil
+}
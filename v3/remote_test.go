@@ -0,0 +1,75 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newRemoteTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(NewRemoteHandler(newTransportTestRegistry()))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRemoteFunction(t *testing.T) {
+	client := NewRemoteClient(newRemoteTestServer(t).URL, nil)
+	ctx := context.Background()
+
+	res, err := RemoteFunction[transportOutput](client, "add")(ctx, transportInput{A: 1, B: 2})
+	if err != nil || res != (transportOutput{Sum: 3}) {
+		t.Errorf("add() = %v, %v", res, err)
+	}
+
+	add, err := RemoteAnyFunction[transportInput, transportOutput](client, "add@^1")
+	if err != nil {
+		t.Fatalf("RemoteAnyFunction() error = %v", err)
+	}
+	if res, err := add.Call(ctx, transportInput{A: 2, B: 2}); err != nil || res != (transportOutput{Sum: 4}) {
+		t.Errorf("add.Call() = %v, %v", res, err)
+	}
+
+	var remoteErr *RemoteError
+	_, err = client.Function("fail", nil)(ctx, nil)
+	if !errors.As(err, &remoteErr) || remoteErr.StatusCode != http.StatusInternalServerError || remoteErr.Message != "boom" {
+		t.Errorf("fail() error = %v", err)
+	}
+	_, err = client.Function("explode", nil)(ctx, nil)
+	if !errors.As(err, &remoteErr) || remoteErr.StatusCode != http.StatusInternalServerError || remoteErr.Message != "function 'explode' panicked: kaboom" {
+		t.Errorf("explode() error = %v", err)
+	}
+	if _, err := client.Function("missing", nil)(ctx, nil); !errors.Is(err, ErrFunctionNotFound) {
+		t.Errorf("missing() error = %v", err)
+	}
+	if _, err := client.Function("add", nil)(ctx, "not an object"); !errors.As(err, &remoteErr) || remoteErr.StatusCode != http.StatusBadRequest || !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("add() with invalid input error = %v", err)
+	}
+	if err := (&RemoteError{Name: "slow", StatusCode: http.StatusGatewayTimeout}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RemoteError(504) should be context.DeadlineExceeded")
+	}
+}
+
+func TestRemoteClientRegisterRemote(t *testing.T) {
+	client := NewRemoteClient(newRemoteTestServer(t).URL, nil)
+	ctx := context.Background()
+
+	info, err := client.Describe(ctx, "add")
+	if err != nil || info.InputType != "v3.transportInput" || info.OutputType != "v3.transportOutput" {
+		t.Errorf("Describe() = %+v, %v", info, err)
+	}
+
+	registry := NewSimpleFunctionRegistry()
+	if err := client.RegisterRemote(ctx, registry); err != nil {
+		t.Fatalf("RegisterRemote() error = %v", err)
+	}
+	if found := registry.FindByTag("math"); len(found) != 1 || found[0].Version != "1.0.0" {
+		t.Errorf("FindByTag() = %v", found)
+	}
+	add, _ := registry.GetFunction("add")
+	res, err := add(ctx, map[string]int{"a": 3, "b": 4})
+	if err != nil || res.(map[string]any)["sum"] != float64(7) {
+		t.Errorf("add() = %v, %v", res, err)
+	}
+}
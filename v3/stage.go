@@ -2,43 +2,237 @@ package v3
 
 import (
 	"context"
-	"sync"
+	"errors"
+	"fmt"
+	v2 "func_decorator/v2"
+	"sort"
+	"time"
 )
 
 type Stage interface {
 	Run(ctx context.Context, input any) ([]any, error)
 }
 
+// ErrorPolicyType | ConcurrentStage 에서 Task 가 실패했을 때의 처리 방식
+type ErrorPolicyType string
+
+const (
+	// WaitAll | 모든 Task 가 끝날 때까지 기다린 뒤, 실패한 Task 가 있으면 index 가 가장 빠른 에러를 반환 (기본값)
+	WaitAll = ErrorPolicyType("wait_all")
+	// FailFast | 하나라도 실패하면 나머지 Task 의 ctx 를 취소하고 바로 에러를 반환
+	FailFast = ErrorPolicyType("fail_fast")
+	// CollectAll | 모든 Task 가 끝날 때까지 기다린 뒤, 모든 결과와 실패한 Task 들의 에러를 함께 반환
+	CollectAll = ErrorPolicyType("collect_all")
+	// Quorum | ErrorPolicy.Quorum 개의 Task 가 성공하면 나머지 Task 의 ctx 를 취소하고 바로 성공
+	Quorum = ErrorPolicyType("quorum")
+)
+
+type ErrorPolicy struct {
+	Type   ErrorPolicyType
+	Quorum int // Type 이 Quorum 일 때 성공해야 하는 Task 의 수
+}
+
+// TaskError | ConcurrentStage 에서 몇 번째 Task 가 실패했는지 나타내는 에러
+type TaskError struct {
+	Index int
+	Err   error
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("task[%d] failed: %v", e.Index, e.Err)
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
 type ConcurrentStage struct {
-	Tasks []Task
+	Tasks      []Task
+	Priorities []int // Tasks 와 같은 index 의 priority (높을수록 먼저 실행, 기본값 0)
+	Policy     ErrorPolicy
+	Pool       *WorkerPool // nil 이면 모든 Task 를 동시에 실행
+}
+
+func (s *ConcurrentStage) priority(i int) int {
+	if i < len(s.Priorities) {
+		return s.Priorities[i]
+	}
+	return 0
 }
 
 func NewConcurrentStage(tasks ...Task) Stage {
-	return &ConcurrentStage{Tasks: tasks}
+	return &ConcurrentStage{Tasks: tasks, Policy: ErrorPolicy{Type: WaitAll}}
+}
+
+type StageBuilder interface {
+	AddTask(tasks ...Task) StageBuilder
+	AddTaskWithPriority(task Task, priority int) StageBuilder
+	ErrorPolicy(policy ErrorPolicy) StageBuilder
+	MaxConcurrency(n int) StageBuilder
+	Pool(pool *WorkerPool) StageBuilder
+	Build() Stage
+}
+
+type stageBuilder struct {
+	stage *ConcurrentStage
+}
+
+func NewStageBuilder() StageBuilder {
+	return &stageBuilder{stage: &ConcurrentStage{Policy: ErrorPolicy{Type: WaitAll}}}
+}
+
+func (b *stageBuilder) AddTask(tasks ...Task) StageBuilder {
+	for _, task := range tasks {
+		b.AddTaskWithPriority(task, 0)
+	}
+	return b
+}
+
+// AddTaskWithPriority | WorkerPool 에 자리가 났을 때 priority 가 높은 Task 부터 실행됩니다.
+func (b *stageBuilder) AddTaskWithPriority(task Task, priority int) StageBuilder {
+	b.stage.Tasks = append(b.stage.Tasks, task)
+	b.stage.Priorities = append(b.stage.Priorities, priority)
+	return b
+}
+
+// MaxConcurrency | Stage 전용 WorkerPool 을 만들어 동시에 실행되는 Task 수를 n 개로 제한합니다.
+// n 이 0 이하라면 NewWorkerPool 과 같이 1 로 간주합니다.
+func (b *stageBuilder) MaxConcurrency(n int) StageBuilder {
+	b.stage.Pool = NewWorkerPool(n)
+	return b
+}
+
+// Pool | 여러 Stage 가 공유하는 WorkerPool 로 동시 실행 수를 제한합니다.
+func (b *stageBuilder) Pool(pool *WorkerPool) StageBuilder {
+	b.stage.Pool = pool
+	return b
 }
 
-func (s *ConcurrentStage) Run(ctx context.Context, input any) ([]any, error) {
-	var wg sync.WaitGroup
-	results := make([]any, len(s.Tasks))
-	errors := make([]error, len(s.Tasks))
+func (b *stageBuilder) ErrorPolicy(policy ErrorPolicy) StageBuilder {
+	b.stage.Policy = policy
+	return b
+}
+
+func (b *stageBuilder) Build() Stage {
+	return b.stage
+}
+
+type taskResult struct {
+	index int
+	res   any
+	err   error
+}
+
+// Run | Tasks 를 동시에 실행합니다.
+// 모든 Task 는 같은 run 에 속하며, Stage 호출의 InvocationID 를 부모로 가집니다.
+// ctx 에 EventBus 가 있으면 모든 Task 가 끝난 뒤 StageFinished Event 를 발행합니다.
+// 실패한 Task 의 처리는 Policy 를 따르며, FailFast, Quorum 은 남은 Task 의 ctx 를 취소합니다.
+// Pool 이 있으면 priority 가 높은 Task 부터 Pool 에 자리가 날 때마다 하나씩 실행됩니다.
+// Task 가 패닉이 나면 그 Task 의 에러로 바꾸고 PanicRecovered Event 를 발행합니다.
+func (s *ConcurrentStage) Run(ctx context.Context, input any) (res []any, err error) {
+	ctx, started := v2.StartRun(ctx)
+	ctx, _ = v2.WithInvocation(ctx)
+	begin := time.Now()
+	defer func() {
+		v2.PublishEvent(ctx, v2.Event{Type: v2.StageFinished, Req: input, Res: res, Err: err, Duration: time.Since(begin)})
+		if started {
+			v2.PublishEvent(ctx, v2.Event{Type: v2.RunFinished, Req: input, Res: res, Err: err, Duration: time.Since(begin)})
+		}
+	}()
 
-	for i, task := range s.Tasks {
-		wg.Add(1)
-		go func(i int, t Task) {
-			defer wg.Done()
-			result, err := t.Execute(ctx, input)
-			results[i] = result
-			errors[i] = err
-		}(i, task)
+	n := len(s.Tasks)
+	if s.Policy.Type == Quorum && (s.Policy.Quorum <= 0 || s.Policy.Quorum > n) {
+		return nil, fmt.Errorf("quorum must be between 1 and %d, got %d", n, s.Policy.Quorum)
 	}
 
-	wg.Wait()
+	taskCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	for _, err := range errors {
+	// 먼저 반환하더라도 남은 Task 가 막히지 않도록 buffer 를 Task 수 만큼 잡음
+	done := make(chan taskResult, n)
+	execute := func(i int) {
+		result, err := callRecovered(taskCtx, fmt.Sprintf("task[%d]", i), s.Tasks[i].Execute, input)
+		done <- taskResult{index: i, res: result, err: err}
+	}
+	if s.Pool == nil {
+		for i := range s.Tasks {
+			go execute(i)
+		}
+	} else {
+		go s.dispatch(taskCtx, done, execute)
+	}
+
+	results := make([]any, n)
+	errs := make([]error, n)
+	succeeded, failed := 0, 0
+	for received := 0; received < n; received++ {
+		r := <-done
+		results[r.index], errs[r.index] = r.res, r.err
+		if r.err != nil {
+			failed++
+		} else {
+			succeeded++
+		}
+
+		switch s.Policy.Type {
+		case FailFast:
+			if r.err != nil {
+				return nil, &TaskError{Index: r.index, Err: r.err}
+			}
+		case Quorum:
+			if succeeded >= s.Policy.Quorum {
+				return results, nil
+			}
+			if failed > n-s.Policy.Quorum {
+				return nil, fmt.Errorf("quorum of %d not reached: %w", s.Policy.Quorum, joinTaskErrors(errs))
+			}
+		}
+	}
+
+	if s.Policy.Type == CollectAll {
+		return results, joinTaskErrors(errs)
+	}
+	for _, err := range errs {
 		if err != nil {
 			return nil, err
 		}
 	}
-
 	return results, nil
 }
+
+// dispatch | priority 가 높은 순 (같으면 index 순) 으로 Pool 의 자리를 얻어 Task 를 실행합니다.
+// 자리를 얻은 Task 만 goroutine 으로 실행되므로, 동시에 떠 있는 goroutine 은 Pool 의 크기를 넘지 않습니다.
+// ctx 가 취소되면 아직 실행하지 못한 Task 는 ctx.Err() 로 끝납니다.
+func (s *ConcurrentStage) dispatch(ctx context.Context, done chan<- taskResult, execute func(i int)) {
+	order := make([]int, len(s.Tasks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return s.priority(order[a]) > s.priority(order[b])
+	})
+
+	for k, i := range order {
+		if err := s.Pool.Acquire(ctx, s.priority(i)); err != nil {
+			for _, rest := range order[k:] {
+				done <- taskResult{index: rest, err: err}
+			}
+			return
+		}
+		go func(i int) {
+			defer s.Pool.Release()
+			execute(i)
+		}(i)
+	}
+}
+
+// joinTaskErrors | 실패한 Task 의 에러를 index 와 함께 하나의 에러로 합칩니다.
+func joinTaskErrors(errs []error) error {
+	taskErrs := make([]error, 0)
+	for i, err := range errs {
+		if err != nil {
+			taskErrs = append(taskErrs, &TaskError{Index: i, Err: err})
+		}
+	}
+	return errors.Join(taskErrs...)
+}
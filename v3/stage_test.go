@@ -0,0 +1,214 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	v2 "func_decorator/v2"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrentStageEvents(t *testing.T) {
+	newTask := func(fail bool) Task {
+		return mustBuild(t, NewTaskBuilder(Composite).
+			AddFunction(func(ctx context.Context, a any) (any, error) { return a, nil }).
+			AttachConverter(func(ctx context.Context, a any) (any, error) {
+				if fail {
+					return nil, errors.New("convert failed")
+				}
+				return a, nil
+			}).
+			AddLastFunction(func(ctx context.Context, a any) (any, error) { return a, nil }))
+	}
+
+	bus := v2.NewEventBus()
+	var mu sync.Mutex
+	counts := make(map[v2.EventType]int)
+	bus.Subscribe(v2.EventListenerFunc(func(ctx context.Context, e v2.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		counts[e.Type]++
+	}))
+
+	ctx := v2.WithEventBus(context.Background(), bus)
+	if _, err := NewConcurrentStage(newTask(false), newTask(true)).Run(ctx, 1); err == nil {
+		t.Fatalf("Run() should return error")
+	}
+
+	want := map[v2.EventType]int{
+		v2.RunStarted:      1,
+		v2.NodeStarted:     3,
+		v2.NodeFinished:    3,
+		v2.ConverterFailed: 1,
+		v2.StageFinished:   1,
+		v2.RunFinished:     1,
+	}
+	for typ, n := range want {
+		if counts[typ] != n {
+			t.Errorf("count of %s = %d, want %d", typ, counts[typ], n)
+		}
+	}
+}
+
+func TestConcurrentStagePlanAndDryRun(t *testing.T) {
+	called := false
+	work := func(ctx context.Context, a any) (any, error) { called = true; return a, nil }
+	task := mustBuild(t, NewTaskBuilder(Composite).
+		AddFunction(work).
+		AttachConverter(func(ctx context.Context, a any) (any, error) { return a.(int) + 1, nil }).
+		AddLastFunction(work))
+	stage := NewConcurrentStage(task, task)
+
+	want := strings.Join([]string{
+		"stage concurrent [parallel x2]",
+		"  task task[0] composite",
+		"    function step[0]",
+		"    converter step[1]",
+		"    function step[2]",
+		"  task task[1] composite",
+		"    function step[0]",
+		"    converter step[1]",
+		"    function step[2]",
+		"",
+	}, "\n")
+	if plan := PlanOf(stage).String(); plan != want {
+		t.Errorf("PlanOf() =\n%s\nwant\n%s", plan, want)
+	}
+
+	dryRun := v2.NewDryRun().Simulate("step[0]", func(ctx context.Context, req any) (any, error) { return 10, nil })
+	res, err := stage.Run(v2.WithDryRun(context.Background(), dryRun), 1)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if called {
+		t.Errorf("real functions should not be called in dry-run")
+	}
+	if res[0] != 11 || res[1] != 11 {
+		t.Errorf("Run() = %v, want [11 11]", res)
+	}
+}
+
+func newFuncTask(fn FunctionType) Task {
+	task := NewCompositeTask()
+	task.AddFunction(fn)
+	return task
+}
+
+func okTask(v any) Task {
+	return newFuncTask(func(ctx context.Context, a any) (any, error) { return v, nil })
+}
+
+func failTask(err error) Task {
+	return newFuncTask(func(ctx context.Context, a any) (any, error) { return nil, err })
+}
+
+// ctx 가 취소될 때까지 기다리는 Task
+func blockingTask(cancelled chan<- struct{}) Task {
+	return newFuncTask(func(ctx context.Context, a any) (any, error) {
+		<-ctx.Done()
+		cancelled <- struct{}{}
+		return nil, ctx.Err()
+	})
+}
+
+func TestConcurrentStageFailFast(t *testing.T) {
+	errFail := errors.New("fail")
+	cancelled := make(chan struct{}, 1)
+	stage := NewStageBuilder().
+		AddTask(blockingTask(cancelled), failTask(errFail)).
+		ErrorPolicy(ErrorPolicy{Type: FailFast}).
+		Build()
+
+	_, err := stage.Run(context.Background(), nil)
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) || taskErr.Index != 1 || !errors.Is(err, errFail) {
+		t.Fatalf("Run() error = %v, want task[1] error", err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Errorf("sibling task should be cancelled")
+	}
+}
+
+func TestConcurrentStageCollectAll(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	stage := NewStageBuilder().
+		AddTask(failTask(errA), okTask(1), failTask(errB)).
+		ErrorPolicy(ErrorPolicy{Type: CollectAll}).
+		Build()
+
+	res, err := stage.Run(context.Background(), nil)
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("Run() error = %v, want both errors", err)
+	}
+	if !strings.Contains(err.Error(), "task[0]") || !strings.Contains(err.Error(), "task[2]") {
+		t.Errorf("Run() error = %v, want task indexes", err)
+	}
+	if len(res) != 3 || res[1] != 1 {
+		t.Errorf("Run() results = %v, want [nil 1 nil]", res)
+	}
+}
+
+func TestConcurrentStagePanic(t *testing.T) {
+	bus := v2.NewEventBus()
+	listener := v2.NewChannelListener(16)
+	bus.Subscribe(listener)
+
+	// predicate 의 패닉은 CompositeTask 밖에서 나므로 Stage 에서 recover 되어야 함
+	panicking := NewConditionalTask().When(func(ctx context.Context, a any) (bool, error) { panic("boom") }, okTask(0))
+	stage := NewStageBuilder().
+		AddTask(okTask(1), panicking).
+		ErrorPolicy(ErrorPolicy{Type: CollectAll}).
+		Build()
+
+	res, err := stage.Run(v2.WithEventBus(context.Background(), bus), nil)
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) || taskErr.Index != 1 || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("Run() error = %v, want task[1] panic error", err)
+	}
+	if len(res) != 2 || res[0] != 1 {
+		t.Errorf("Run() results = %v, want [1 nil]", res)
+	}
+	for len(listener.Events()) > 0 {
+		if e := <-listener.Events(); e.Type == v2.PanicRecovered {
+			if e.NodeID != "task[1]" {
+				t.Errorf("PanicRecovered NodeID = %s, want task[1]", e.NodeID)
+			}
+			return
+		}
+	}
+	t.Errorf("PanicRecovered should be published")
+}
+
+func TestConcurrentStageQuorum(t *testing.T) {
+	cancelled := make(chan struct{}, 1)
+	stage := NewStageBuilder().
+		AddTask(okTask(1), failTask(errors.New("fail")), okTask(3), blockingTask(cancelled)).
+		ErrorPolicy(ErrorPolicy{Type: Quorum, Quorum: 2}).
+		Build()
+
+	res, err := stage.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if res[0] != 1 || res[2] != 3 {
+		t.Errorf("Run() results = %v", res)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Errorf("remaining task should be cancelled")
+	}
+
+	// 성공 가능한 Task 가 quorum 보다 적은 경우
+	stage = NewStageBuilder().
+		AddTask(okTask(1), failTask(errors.New("a")), failTask(errors.New("b"))).
+		ErrorPolicy(ErrorPolicy{Type: Quorum, Quorum: 2}).
+		Build()
+	if _, err := stage.Run(context.Background(), nil); err == nil {
+		t.Errorf("Run() should fail when quorum is not reached")
+	}
+}
@@ -0,0 +1,30 @@
+package v3
+
+import (
+	v2 "func_decorator/v2"
+)
+
+// StructConverter | Src 의 field 를 Dst 로 복사하는 converter 를 만듭니다.
+// field 대응 규칙(이름, `fd` tag, mappings)은 여기서 한 번만 검사하며, 맞지 않으면 에러를 반환합니다.
+func StructConverter[Src any, Dst any](mappings ...v2.FieldMapping) (FunctionType, error) {
+	mapper, err := v2.NewStructMapperFor[Src, Dst](mappings...)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.Func(), nil
+}
+
+// TypedStructConverter | StructConverter 를 BuildTypedTask 에서 사용할 수 있는 converter step 으로 만듭니다.
+func TypedStructConverter[Src any, Dst any](name string, mappings ...v2.FieldMapping) (TypedStep, error) {
+	fn, err := StructConverter[Src, Dst](mappings...)
+	if err != nil {
+		return nil, err
+	}
+	return &typedStep{
+		name:      name,
+		converter: true,
+		inType:    v2.GetGenericType[Src](),
+		outType:   v2.GetGenericType[Dst](),
+		fn:        fn,
+	}, nil
+}
@@ -0,0 +1,35 @@
+package v3
+
+import (
+	"context"
+	v2 "func_decorator/v2"
+	"testing"
+)
+
+type mappedOutput struct{ Result int }
+type mappedInput struct{ A, B int }
+
+func TestTypedStructConverter(t *testing.T) {
+	sum := Typed("sum", func(ctx context.Context, in sumInput) (mappedOutput, error) {
+		return mappedOutput{Result: in.A + in.B}, nil
+	})
+	toInput, err := TypedStructConverter[mappedOutput, sumInput]("toInput",
+		v2.FieldMapping{From: "Result", To: "A"}, v2.FieldMapping{From: "Result", To: "B"})
+	if err != nil {
+		t.Fatalf("TypedStructConverter() error = %v", err)
+	}
+	sumAgain := Typed("sumAgain", func(ctx context.Context, in sumInput) (int, error) { return in.A + in.B, nil })
+
+	task, err := BuildTypedTask[sumInput, int](sum, toInput, sumAgain)
+	if err != nil {
+		t.Fatalf("BuildTypedTask() error = %v", err)
+	}
+	res, err := task.Execute(context.Background(), sumInput{A: 1, B: 2})
+	if err != nil || res != 6 {
+		t.Errorf("Execute() = (%v, %v), want 6", res, err)
+	}
+
+	if _, err := StructConverter[mappedOutput, mappedInput](v2.FieldMapping{From: "Missing", To: "A"}); err == nil {
+		t.Error("StructConverter() with unknown field should fail")
+	}
+}
@@ -2,74 +2,344 @@ package v3
 
 import (
 	"context"
+	"fmt"
+	v2 "func_decorator/v2"
+	"reflect"
+	"time"
 )
 
 type TaskType string
 
 const (
-	Composite = TaskType("composite")
+	Composite   = TaskType("composite")
+	Sequential  = TaskType("sequential")
+	Conditional = TaskType("conditional")
+	Loop        = TaskType("loop")
+	Switch      = TaskType("switch")
 )
 
 type TaskBuilder interface {
 	AddFunction(fn FunctionType) TaskConverterBuilder
 	AddLastFunction(fn FunctionType) TaskBuilder
-	Build() Task
+	// AddNamedFunction | 이름으로 기록, 조회할 수 있는 function 을 추가합니다.
+	AddNamedFunction(name string, fn FunctionType) TaskConverterBuilder
+	AddLastNamedFunction(name string, fn FunctionType) TaskBuilder
+	// AddTask | Sequential : 순서대로 실행할 sub-task, Loop : 각 원소에 순서대로 적용할 sub-task
+	AddTask(task Task) TaskBuilder
+	// When | Conditional : predicate 가 true 일 때 실행할 branch
+	When(predicate Predicate, task Task) TaskBuilder
+	// Discriminator | Switch : 실행할 case 의 key 를 고르는 func
+	Discriminator(fn DiscriminatorFunc) TaskBuilder
+	// Case | Switch : key 에 해당하는 case
+	Case(key any, task Task) TaskBuilder
+	// Otherwise | Conditional, Switch : 일치하는 branch 가 없을 때 실행할 Task
+	Otherwise(task Task) TaskBuilder
+	// AddTypedFunction | 요청, 응답 타입을 아는 step 을 추가합니다. 앞 step 과 타입이 다르면 Build 에서 converter 를 찾아 끼워 넣습니다.
+	AddTypedFunction(step TypedStep) TaskConverterBuilder
+	// Converters | AddTypedFunction 으로 추가된 step 사이의 converter 를 찾을 ConverterRegistry
+	Converters(registry *v2.ConverterRegistry) TaskBuilder
+	// Build | 추가된 step 의 순서와 짝이 올바른지 검사하고 Task 를 만듭니다.
+	Build() (Task, error)
 }
 
+// TaskConverterBuilder | function 뒤에는 converter 를 붙이거나, 타입이 이미 맞다면 바로 다음 function 을 이어서 추가할 수 있음
 type TaskConverterBuilder interface {
 	AttachConverter(fn FunctionType) TaskBuilder
+	AttachNamedConverter(name string, fn FunctionType) TaskBuilder
+	AddFunction(fn FunctionType) TaskConverterBuilder
+	AddLastFunction(fn FunctionType) TaskBuilder
+	AddNamedFunction(name string, fn FunctionType) TaskConverterBuilder
+	AddLastNamedFunction(name string, fn FunctionType) TaskBuilder
+	AddTypedFunction(step TypedStep) TaskConverterBuilder
+	Build() (Task, error)
+}
+
+type stepKind string
+
+const (
+	functionStep  = stepKind("function")
+	converterStep = stepKind("converter")
+)
+
+// taskStep | builder 에 추가된 순서대로 기록되는 step
+type taskStep struct {
+	kind stepKind
+	name string
+	fn   FunctionType
+	last bool // AddLastFunction 으로 추가된 step
+	// AddTypedFunction 으로 추가된 step 만 타입을 알 수 있음
+	inType  reflect.Type
+	outType reflect.Type
 }
 
 type taskBuilder struct {
-	taskType TaskType
-	fns      []FunctionType
-	cvts     []FunctionType
+	taskType      TaskType
+	steps         []taskStep
+	tasks         []Task
+	branches      []conditionalBranch
+	discriminator DiscriminatorFunc
+	cases         []switchCase
+	otherwise     Task
+	converters    *v2.ConverterRegistry
+}
+
+type switchCase struct {
+	key  any
+	task Task
 }
 
 func NewTaskBuilder(t TaskType) TaskBuilder {
 	return &taskBuilder{
 		taskType: t,
-		fns:      make([]FunctionType, 0),
-		cvts:     make([]FunctionType, 0),
+		steps:    make([]taskStep, 0),
 	}
 }
 
 func (t *taskBuilder) AddFunction(fn FunctionType) TaskConverterBuilder {
-	t.fns = append(t.fns, fn)
-	return t
+	return t.AddNamedFunction("", fn)
 }
 
 func (t *taskBuilder) AddLastFunction(fn FunctionType) TaskBuilder {
-	t.fns = append(t.fns, fn)
-	return t
+	return t.AddLastNamedFunction("", fn)
 }
 
 func (t *taskBuilder) AttachConverter(fn FunctionType) TaskBuilder {
-	t.cvts = append(t.cvts, fn)
+	return t.AttachNamedConverter("", fn)
+}
+
+func (t *taskBuilder) AddNamedFunction(name string, fn FunctionType) TaskConverterBuilder {
+	t.steps = append(t.steps, taskStep{kind: functionStep, name: name, fn: fn})
+	return t
+}
+
+func (t *taskBuilder) AddLastNamedFunction(name string, fn FunctionType) TaskBuilder {
+	t.steps = append(t.steps, taskStep{kind: functionStep, name: name, fn: fn, last: true})
+	return t
+}
+
+func (t *taskBuilder) AttachNamedConverter(name string, fn FunctionType) TaskBuilder {
+	t.steps = append(t.steps, taskStep{kind: converterStep, name: name, fn: fn})
+	return t
+}
+
+func (t *taskBuilder) AddTypedFunction(step TypedStep) TaskConverterBuilder {
+	kind := functionStep
+	if step.IsConverter() {
+		kind = converterStep
+	}
+	t.steps = append(t.steps, taskStep{kind: kind, name: step.Name(), fn: step.Func(), inType: step.InType(), outType: step.OutType()})
 	return t
 }
 
-func (t *taskBuilder) Build() Task {
-	var task Task
+func (t *taskBuilder) Converters(registry *v2.ConverterRegistry) TaskBuilder {
+	t.converters = registry
+	return t
+}
+
+// resolveConverters | 타입을 아는 두 function 이 converter 없이 이어져 있고 타입이 다르다면,
+// ConverterRegistry 에서 찾은 converter chain 을 사이에 끼워 넣은 step 목록을 반환합니다.
+func (t *taskBuilder) resolveConverters() ([]taskStep, error) {
+	steps := make([]taskStep, 0, len(t.steps))
+	for i, step := range t.steps {
+		if i > 0 && step.kind == functionStep && t.steps[i-1].kind == functionStep {
+			prev := t.steps[i-1]
+			if prev.outType != nil && step.inType != nil && !prev.outType.AssignableTo(step.inType) {
+				if t.converters == nil {
+					return nil, fmt.Errorf("%w, attach a converter or set Converters", &v2.TypeMismatchError{
+						From: fmt.Sprintf("step[%d]", i-1), FromType: prev.outType, To: fmt.Sprintf("step[%d]", i), ToType: step.inType,
+					})
+				}
+				chain, err := t.converters.Resolve(prev.outType, step.inType)
+				if err != nil {
+					return nil, fmt.Errorf("step[%d] -> step[%d]: %w", i-1, i, err)
+				}
+				steps = append(steps, taskStep{kind: converterStep, fn: chainConverter(chain), inType: prev.outType, outType: step.inType})
+			}
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// chainConverter | converter chain 을 순서대로 호출하는 하나의 converter 로 만듭니다.
+func chainConverter(chain []v2.AnyFunction) FunctionType {
+	return func(ctx context.Context, input any) (any, error) {
+		var err error
+		for _, converter := range chain {
+			if input, err = converter.Call(ctx, input); err != nil {
+				return nil, err
+			}
+		}
+		return input, nil
+	}
+}
+
+// validateSteps | step 목록이 올바른 모양인지 검사합니다.
+// converter 는 반드시 function 사이에 하나만 올 수 있고, AddLastFunction 뒤에는 어떤 step 도 올 수 없음
+func (t *taskBuilder) validateSteps() error {
+	names := make(map[string]int)
+	for i, step := range t.steps {
+		if step.fn == nil {
+			return fmt.Errorf("step[%d] (%s) is nil", i, step.kind)
+		}
+		if step.name != "" {
+			if prev, exists := names[step.name]; exists {
+				return fmt.Errorf("step[%d] and step[%d] have the same name '%s'", prev, i, step.name)
+			}
+			names[step.name] = i
+		}
+		if i > 0 && t.steps[i-1].last {
+			return fmt.Errorf("step[%d] (%s) is added after the last function (step[%d])", i, step.kind, i-1)
+		}
+		if step.kind != converterStep {
+			continue
+		}
+		if i == 0 {
+			return fmt.Errorf("step[0] is a converter, but a converter must follow a function")
+		}
+		if t.steps[i-1].kind == converterStep {
+			return fmt.Errorf("step[%d] and step[%d] are both converters, a converter must be attached to a function", i-1, i)
+		}
+		if i == len(t.steps)-1 {
+			return fmt.Errorf("step[%d] is a converter, but a converter must be followed by a function", i)
+		}
+	}
+	return nil
+}
+
+// validateShape | taskType 에 필요한 설정이 되어 있는지 검사합니다.
+func (t *taskBuilder) validateShape() error {
 	switch t.taskType {
-	case Composite:
-		task = NewCompositeTask()
+	case Composite, "":
+		if len(t.steps) == 0 {
+			return fmt.Errorf("%s task has no function", Composite)
+		}
+	case Sequential:
+		if len(t.steps) == 0 && len(t.tasks) == 0 {
+			return fmt.Errorf("%s task has no task or function", Sequential)
+		}
+	case Conditional:
+		if len(t.branches) == 0 {
+			return fmt.Errorf("%s task has no branch (When)", Conditional)
+		}
+	case Loop:
+		if len(t.steps) == 0 && len(t.tasks) == 0 {
+			return fmt.Errorf("%s task has no body task or function", Loop)
+		}
+	case Switch:
+		if t.discriminator == nil {
+			return fmt.Errorf("%s task has no discriminator", Switch)
+		}
+		if len(t.cases) == 0 {
+			return fmt.Errorf("%s task has no case", Switch)
+		}
+		for i, c := range t.cases {
+			if err := checkSwitchKey(c.key); err != nil {
+				return fmt.Errorf("%s case[%d]: %w", Switch, i, err)
+			}
+		}
 	default:
-		task = NewCompositeTask()
+		return fmt.Errorf("unknown task type '%s'", t.taskType)
 	}
+	return nil
+}
 
-	// fns 가 cvts 보다는 많을 것
-	lenFn, lenCvt := len(t.fns), len(t.cvts)
+func (t *taskBuilder) AddTask(task Task) TaskBuilder {
+	t.tasks = append(t.tasks, task)
+	return t
+}
 
-	for i := 0; i < lenFn; i++ {
-		if i < lenFn {
-			task.AddFunction(t.fns[i])
+func (t *taskBuilder) When(predicate Predicate, task Task) TaskBuilder {
+	t.branches = append(t.branches, conditionalBranch{predicate: predicate, task: task})
+	return t
+}
+
+func (t *taskBuilder) Discriminator(fn DiscriminatorFunc) TaskBuilder {
+	t.discriminator = fn
+	return t
+}
+
+func (t *taskBuilder) Case(key any, task Task) TaskBuilder {
+	t.cases = append(t.cases, switchCase{key: key, task: task})
+	return t
+}
+
+func (t *taskBuilder) Otherwise(task Task) TaskBuilder {
+	t.otherwise = task
+	return t
+}
+
+// Build | taskType 에 맞는 Task 를 만듭니다.
+// AddFunction 으로 추가한 function 은 하나의 CompositeTask 로 모여 Composite 은 그대로, Sequential 은 마지막 sub-task 로,
+// Loop 는 body 의 마지막으로, Conditional, Switch 는 Otherwise 뒤에 실행되도록 추가됩니다.
+// AddTask, Otherwise 로 넘긴 Task 는 수정하지 않습니다.
+func (t *taskBuilder) Build() (Task, error) {
+	if err := t.validateShape(); err != nil {
+		return nil, err
+	}
+	if err := t.validateSteps(); err != nil {
+		return nil, err
+	}
+	steps, err := t.resolveConverters()
+	if err != nil {
+		return nil, err
+	}
+
+	// AddFunction 등으로 추가된 step 은 하나의 CompositeTask 로 모아 taskType 에 맞는 자리에 붙입니다.
+	var composite *CompositeTask
+	if len(steps) > 0 {
+		composite = NewCompositeTask()
+		for _, step := range steps {
+			composite.AddStep(Step{Name: step.name, Converter: step.kind == converterStep, Fn: step.fn})
 		}
-		if i < lenCvt {
-			task.AddFunction(t.cvts[i])
+	}
+
+	switch t.taskType {
+	case Sequential:
+		return NewSequentialTask(appendTask(t.tasks, composite)...), nil
+	case Conditional:
+		conditional := NewConditionalTask()
+		conditional.branches = t.branches
+		conditional.Otherwise = joinTasks(appendTask([]Task{t.otherwise}, composite))
+		return conditional, nil
+	case Loop:
+		return NewLoopTask(joinTasks(appendTask(t.tasks, composite))), nil
+	case Switch:
+		sw := NewSwitchTask(t.discriminator)
+		for _, c := range t.cases {
+			sw.Case(c.key, c.task)
 		}
+		sw.Default = joinTasks(appendTask([]Task{t.otherwise}, composite))
+		return sw, nil
+	default:
+		return composite, nil
+	}
+}
+
+// appendTask | nil 이 아닌 task 만 tasks 뒤에 붙인 새 slice 를 반환합니다.
+func appendTask(tasks []Task, task *CompositeTask) []Task {
+	joined := make([]Task, 0, len(tasks)+1)
+	for _, t := range tasks {
+		if t != nil {
+			joined = append(joined, t)
+		}
+	}
+	if task != nil {
+		joined = append(joined, task)
+	}
+	return joined
+}
+
+// joinTasks | tasks 가 없으면 nil, 하나면 그대로, 여럿이면 순서대로 실행하는 SequentialTask 를 반환합니다.
+func joinTasks(tasks []Task) Task {
+	switch len(tasks) {
+	case 0:
+		return nil
+	case 1:
+		return tasks[0]
+	default:
+		return NewSequentialTask(tasks...)
 	}
-	return task
 }
 
 type Task interface {
@@ -77,30 +347,196 @@ type Task interface {
 	AddFunction(fn FunctionType)
 }
 
+// Step | CompositeTask 의 한 단계
+// Name 이 비어 있으면 "step[i]" 로 불리며, Converter 라면 Event 발행 시 converter 로 구분됩니다.
+type Step struct {
+	Name      string
+	Converter bool
+	Fn        FunctionType
+}
+
+// CompositeTask | Steps 를 순서대로 실행하는 Task
+// 이전의 Functions []FunctionType 필드는 이름과 converter 여부를 함께 담는 Steps 로 바뀌었으며,
+// Functions 필드를 직접 읽거나 쓰던 코드는 Steps 의 Fn 을 사용하도록 고쳐야 합니다.
 type CompositeTask struct {
-	Functions []FunctionType
+	Steps []Step
 }
 
 func NewCompositeTask() *CompositeTask {
 	return &CompositeTask{
-		Functions: []FunctionType{},
+		Steps: []Step{},
 	}
 }
 
 func (t *CompositeTask) AddFunction(fn FunctionType) {
-	t.Functions = append(t.Functions, fn)
+	t.AddStep(Step{Fn: fn})
 }
 
+// AddConverter | 앞 function 의 output 을 다음 function 의 input 으로 변환하는 converter 를 추가합니다.
+func (t *CompositeTask) AddConverter(fn FunctionType) {
+	t.AddStep(Step{Converter: true, Fn: fn})
+}
+
+// AddStep | 이름, converter 여부를 지정한 step 을 추가합니다.
+func (t *CompositeTask) AddStep(step Step) {
+	t.Steps = append(t.Steps, step)
+}
+
+// stepName | i 번째 step 의 이름을 반환합니다.
+func (t *CompositeTask) stepName(i int) string {
+	if name := t.Steps[i].Name; name != "" {
+		return name
+	}
+	return fmt.Sprintf("step[%d]", i)
+}
+
+// Execute | Steps 를 순서대로 실행하고 마지막 step 의 output 을 반환합니다.
 func (t *CompositeTask) Execute(ctx context.Context, input any) (any, error) {
+	record, err := t.ExecuteWithRecord(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return record.Output(), nil
+}
+
+// ExecuteWithRecord | Steps 를 순서대로 실행하고 모든 step 의 input, output 기록을 반환합니다.
+// 실패한 경우에도 실패한 step 까지의 기록을 반환합니다.
+// Task 와 각 step 은 ctx 의 run 에 속한 InvocationID 를 발급받습니다. (run 이 없으면 새로 시작)
+// 실행 중인 step 은 StepOutput, StepInput 으로 앞서 실행된 step 의 기록을 조회할 수 있습니다.
+// ctx 에 EventBus 가 있으면 step 의 lifecycle Event 를 발행하고,
+// step 이 패닉이 나면 StepError 로 바꾸고 PanicRecovered Event 를 발행합니다.
+// ctx 에 v2.Replay 가 있으면 대체 지정된 step 은 기록된 output 을 사용하고,
+// ctx 에 v2.DryRun 이 있으면 function 대신 stub 을 호출합니다. (step 이름으로 지정)
+func (t *CompositeTask) ExecuteWithRecord(ctx context.Context, input any) (record *StepRecord, err error) {
+	ctx, started := v2.StartRun(ctx)
+	if started {
+		begin := time.Now()
+		defer func() {
+			v2.PublishEvent(ctx, v2.Event{Type: v2.RunFinished, Req: input, Res: record.Output(), Err: err, Duration: time.Since(begin)})
+		}()
+	}
+
+	parent, _ := GetStepRecord(ctx)
+	record = &StepRecord{Input: input, parent: parent}
+	ctx = context.WithValue(ctx, stepRecordKey{}, record)
+
 	var currentInput any = input
-	var err error
 
-	for _, fn := range t.Functions {
-		currentInput, err = fn(ctx, currentInput)
+	ctx, record.ExecutionInfo = v2.WithInvocation(ctx)
+	for i, step := range t.Steps {
+		stepCtx, info := v2.WithInvocation(ctx)
+		stepName := t.stepName(i)
+		stepInput := currentInput
+
+		begin := time.Now()
+		if step.Converter {
+			var simulated bool
+			if currentInput, simulated, err = v2.SimulatedConverterOutput(stepCtx, stepName, stepInput); !simulated {
+				currentInput, err = callRecovered(stepCtx, stepName, step.Fn, stepInput)
+			}
+			if err != nil {
+				v2.PublishEvent(stepCtx, v2.Event{Type: v2.ConverterFailed, NodeID: stepName, Req: stepInput, Err: err, Duration: time.Since(begin)})
+			} else {
+				v2.PublishEvent(stepCtx, v2.Event{Type: v2.ConverterFinished, NodeID: stepName, Req: stepInput, Res: currentInput, Duration: time.Since(begin)})
+			}
+		} else {
+			v2.PublishEvent(stepCtx, v2.Event{Type: v2.NodeStarted, NodeID: stepName, Req: stepInput})
+			var substituted bool
+			if currentInput, substituted, err = v2.SubstitutedOutput(stepCtx, stepName, nil, stepInput); !substituted {
+				currentInput, err = callRecovered(stepCtx, stepName, step.Fn, stepInput)
+			}
+			v2.PublishEvent(stepCtx, v2.Event{Type: v2.NodeFinished, NodeID: stepName, Req: stepInput, Res: currentInput, Err: err, Duration: time.Since(begin)})
+		}
+
+		record.Results = append(record.Results, StepResult{
+			ExecutionInfo: info,
+			Name:          stepName,
+			Converter:     step.Converter,
+			Input:         stepInput,
+			Output:        currentInput,
+			Err:           err,
+			Duration:      time.Since(begin),
+		})
 		if err != nil {
-			return nil, err
+			return record, &StepError{ExecutionInfo: info, Step: i, Name: stepName, Err: err}
 		}
 	}
 
-	return currentInput, nil
+	return record, nil
+}
+
+// callRecovered | fn 을 호출하고, 패닉이 나면 에러로 바꿔 PanicRecovered Event 를 발행합니다.
+func callRecovered(ctx context.Context, name string, fn FunctionType, input any) (res any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			res, err = nil, v2.RecoveredPanic(ctx, name, input, r)
+		}
+	}()
+	return fn(ctx, input)
+}
+
+// StepResult | 실행된 step 하나의 기록, ExecutionInfo 는 step 호출의 run, invocation
+type StepResult struct {
+	v2.ExecutionInfo
+	Name      string
+	Converter bool
+	Input     any
+	Output    any
+	Err       error
+	Duration  time.Duration
+}
+
+// StepRecord | CompositeTask 한 번의 실행에서 실행된 step 들의 기록, ExecutionInfo 는 Task 호출의 run, invocation
+type StepRecord struct {
+	v2.ExecutionInfo
+	Input   any
+	Results []StepResult
+	parent  *StepRecord // CompositeTask 안에서 실행된 CompositeTask 라면 바깥 Task 의 기록
+}
+
+// Get | name 의 step 기록을 반환합니다. 없으면 바깥 Task 의 기록에서 찾습니다.
+func (r *StepRecord) Get(name string) (StepResult, bool) {
+	if r == nil {
+		return StepResult{}, false
+	}
+	for _, result := range r.Results {
+		if result.Name == name {
+			return result, true
+		}
+	}
+	return r.parent.Get(name)
+}
+
+// Output | 마지막 step 의 output 을 반환합니다. 실행된 step 이 없으면 Task 의 input 을 반환합니다.
+func (r *StepRecord) Output() any {
+	if r == nil {
+		return nil
+	}
+	if len(r.Results) == 0 {
+		return r.Input
+	}
+	return r.Results[len(r.Results)-1].Output
+}
+
+// stepRecordKey | context 에 실행 중인 CompositeTask 의 StepRecord 를 저장할 때 사용하는 key
+type stepRecordKey struct{}
+
+// GetStepRecord | 실행 중인 CompositeTask 의 StepRecord 를 반환합니다.
+func GetStepRecord(ctx context.Context) (*StepRecord, bool) {
+	record, ok := ctx.Value(stepRecordKey{}).(*StepRecord)
+	return record, ok
+}
+
+// StepOutput | 앞서 실행된 name 의 step output 을 반환합니다.
+func StepOutput(ctx context.Context, name string) (any, bool) {
+	record, _ := GetStepRecord(ctx)
+	result, ok := record.Get(name)
+	return result.Output, ok
+}
+
+// StepInput | 앞서 실행된 name 의 step input 을 반환합니다.
+func StepInput(ctx context.Context, name string) (any, bool) {
+	record, _ := GetStepRecord(ctx)
+	result, ok := record.Get(name)
+	return result.Input, ok
 }
@@ -0,0 +1,340 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	v2 "func_decorator/v2"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCompositeTaskExecute(t *testing.T) {
+	task := mustBuild(t, NewTaskBuilder(Composite).
+		AddFunction(func(ctx context.Context, a any) (any, error) { return a.(int) + 1, nil }).
+		AttachConverter(func(ctx context.Context, a any) (any, error) { return a.(int) * 10, nil }).
+		AddLastFunction(func(ctx context.Context, a any) (any, error) { return a.(int) - 1, nil }))
+
+	res, err := task.Execute(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if res != 19 {
+		t.Errorf("Execute() = %v, want 19", res)
+	}
+}
+
+// Stage 내의 모든 Task, step 이 같은 run 에 속하는지 검증
+func TestRunCorrelation(t *testing.T) {
+	var mu sync.Mutex
+	infos := make([]v2.ExecutionInfo, 0)
+	record := func(ctx context.Context, a any) (any, error) {
+		info, _ := v2.GetExecutionInfo(ctx)
+		mu.Lock()
+		infos = append(infos, info)
+		mu.Unlock()
+		return a, nil
+	}
+
+	newTask := func() Task {
+		task := NewCompositeTask()
+		task.AddFunction(record)
+		task.AddFunction(record)
+		return task
+	}
+	stage := NewConcurrentStage(newTask(), newTask(), newTask())
+	if _, err := stage.Run(context.Background(), 1); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(infos) != 6 {
+		t.Fatalf("len(infos) = %d, want 6", len(infos))
+	}
+	seen := make(map[string]bool)
+	for _, info := range infos {
+		if info.RunID == "" || info.RunID != infos[0].RunID {
+			t.Errorf("RunID = %s, want %s", info.RunID, infos[0].RunID)
+		}
+		if seen[info.InvocationID] {
+			t.Errorf("InvocationID %s is duplicated", info.InvocationID)
+		}
+		seen[info.InvocationID] = true
+	}
+}
+
+func TestStepRecordExecutionInfo(t *testing.T) {
+	task := NewCompositeTask()
+	task.AddFunction(func(ctx context.Context, a any) (any, error) { return a, nil })
+	task.AddFunction(func(ctx context.Context, a any) (any, error) { return a, nil })
+
+	record, err := task.ExecuteWithRecord(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ExecuteWithRecord() error = %v", err)
+	}
+	if record.RunID == "" || record.InvocationID == "" {
+		t.Fatalf("record = %+v, want run and invocation IDs", record.ExecutionInfo)
+	}
+	for _, result := range record.Results {
+		if result.RunID != record.RunID || result.ParentInvocationID != record.InvocationID {
+			t.Errorf("step %s = %s, want child of %s", result.Name, result.ExecutionInfo, record.ExecutionInfo)
+		}
+	}
+}
+
+func TestCompositeTaskStepError(t *testing.T) {
+	errFail := errors.New("fail")
+	task := NewCompositeTask()
+	task.AddFunction(func(ctx context.Context, a any) (any, error) { return a, nil })
+	task.AddFunction(func(ctx context.Context, a any) (any, error) { return nil, errFail })
+
+	_, err := task.Execute(context.Background(), 1)
+	var stepErr *StepError
+	if !errors.As(err, &stepErr) {
+		t.Fatalf("Execute() error = %v, want *StepError", err)
+	}
+	if stepErr.Step != 1 || stepErr.RunID == "" || !errors.Is(err, errFail) {
+		t.Errorf("unexpected StepError = %+v", stepErr)
+	}
+}
+
+func TestCompositeTaskPanic(t *testing.T) {
+	bus := v2.NewEventBus()
+	listener := v2.NewChannelListener(16)
+	bus.Subscribe(listener)
+
+	task := NewCompositeTask()
+	task.AddFunction(func(ctx context.Context, a any) (any, error) { return a, nil })
+	task.AddStep(Step{Name: "explode", Fn: func(ctx context.Context, a any) (any, error) { panic("boom") }})
+
+	_, err := task.Execute(v2.WithEventBus(context.Background(), bus), 1)
+	var stepErr *StepError
+	if !errors.As(err, &stepErr) || stepErr.Name != "explode" || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("Execute() error = %v, want StepError of 'explode'", err)
+	}
+	for len(listener.Events()) > 0 {
+		if e := <-listener.Events(); e.Type == v2.PanicRecovered {
+			if e.NodeID != "explode" {
+				t.Errorf("PanicRecovered NodeID = %s, want explode", e.NodeID)
+			}
+			return
+		}
+	}
+	t.Errorf("PanicRecovered should be published")
+}
+
+func TestCompositeTaskReplay(t *testing.T) {
+	newTask := func(fn FunctionType) Task {
+		task := NewCompositeTask()
+		task.AddFunction(fn)
+		task.AddConverter(func(ctx context.Context, a any) (any, error) { return a.(int) + 1, nil })
+		return task
+	}
+
+	recorder := v2.NewTraceRecorder()
+	bus := v2.NewEventBus()
+	bus.Subscribe(recorder)
+	ctx := v2.WithEventBus(context.Background(), bus)
+	if _, err := newTask(func(ctx context.Context, a any) (any, error) { return 41, nil }).Execute(ctx, 0); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	trace := recorder.Trace()
+	if len(trace.Entries) != 2 || trace.Entries[1].Kind != v2.TraceConverter {
+		t.Fatalf("unexpected trace = %+v", trace.Entries)
+	}
+
+	replay := v2.NewReplay(trace).Substitute("step[0]", reflect.TypeOf(0))
+	failing := newTask(func(ctx context.Context, a any) (any, error) { return nil, errors.New("fail") })
+	res, err := failing.Execute(v2.WithReplay(context.Background(), replay), 0)
+	if err != nil || res != 42 {
+		t.Errorf("Execute() with replay = (%v, %v), want 42", res, err)
+	}
+}
+
+func mustBuild(t *testing.T, b interface{ Build() (Task, error) }) Task {
+	t.Helper()
+	task, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return task
+}
+
+func TestTaskBuilderValidation(t *testing.T) {
+	identity := func(ctx context.Context, a any) (any, error) { return a, nil }
+
+	tests := []struct {
+		name    string
+		builder func() TaskBuilder
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			builder: func() TaskBuilder { return NewTaskBuilder(Composite) },
+			wantErr: "has no function",
+		},
+		{
+			name: "converter after last function",
+			builder: func() TaskBuilder {
+				b := NewTaskBuilder(Composite)
+				b.AddLastFunction(identity)
+				return b.(*taskBuilder).AttachConverter(identity)
+			},
+			wantErr: "after the last function",
+		},
+		{
+			name: "converter without next function",
+			builder: func() TaskBuilder {
+				return NewTaskBuilder(Composite).AddFunction(identity).AttachConverter(identity)
+			},
+			wantErr: "must be followed by a function",
+		},
+		{
+			name: "first step is converter",
+			builder: func() TaskBuilder {
+				b := NewTaskBuilder(Composite)
+				b.(*taskBuilder).AttachConverter(identity)
+				b.AddLastFunction(identity)
+				return b
+			},
+			wantErr: "must follow a function",
+		},
+		{
+			name: "consecutive converters",
+			builder: func() TaskBuilder {
+				return NewTaskBuilder(Composite).AddFunction(identity).AttachConverter(identity).(*taskBuilder).
+					AttachConverter(identity).AddLastFunction(identity)
+			},
+			wantErr: "both converters",
+		},
+		{
+			name:    "nil function",
+			builder: func() TaskBuilder { return NewTaskBuilder(Composite).AddLastFunction(nil) },
+			wantErr: "is nil",
+		},
+		{
+			name:    "switch without discriminator",
+			builder: func() TaskBuilder { return NewTaskBuilder(Switch).Case(1, NewCompositeTask()) },
+			wantErr: "no discriminator",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder().Build()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Build() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// 타입이 이미 맞는 function 은 converter 없이 이어 붙일 수 있음
+func TestTaskBuilderWithoutConverter(t *testing.T) {
+	inc := func(ctx context.Context, a any) (any, error) { return a.(int) + 1, nil }
+	task := mustBuild(t, NewTaskBuilder(Composite).AddFunction(inc).AddFunction(inc).AddLastFunction(inc))
+
+	if res, err := task.Execute(context.Background(), 0); err != nil || res != 3 {
+		t.Errorf("Execute() = (%v, %v), want 3", res, err)
+	}
+}
+
+func TestCompositeTaskNamedSteps(t *testing.T) {
+	add := func(ctx context.Context, a any) (any, error) {
+		in := a.([2]int)
+		return in[0] + in[1], nil
+	}
+	// add 의 input 을 output 과 함께 넘기지 않고, step 기록에서 조회
+	toMultiplyInput := func(ctx context.Context, a any) (any, error) {
+		addInput, ok := StepInput(ctx, "add")
+		if !ok {
+			return nil, errors.New("add input not found")
+		}
+		return [2]int{addInput.([2]int)[0], a.(int)}, nil
+	}
+	multiply := func(ctx context.Context, a any) (any, error) {
+		in := a.([2]int)
+		return in[0] * in[1], nil
+	}
+
+	task := mustBuild(t, NewTaskBuilder(Composite).
+		AddNamedFunction("add", add).
+		AttachNamedConverter("toMultiplyInput", toMultiplyInput).
+		AddLastNamedFunction("multiply", multiply))
+
+	record, err := task.(*CompositeTask).ExecuteWithRecord(context.Background(), [2]int{10, 20})
+	if err != nil {
+		t.Fatalf("ExecuteWithRecord() error = %v", err)
+	}
+	if record.Output() != 300 {
+		t.Errorf("Output() = %v, want 300", record.Output())
+	}
+	if add, ok := record.Get("add"); !ok || add.Output != 30 {
+		t.Errorf("Get(add) = %+v", add)
+	}
+	if cvt, ok := record.Get("toMultiplyInput"); !ok || !cvt.Converter {
+		t.Errorf("Get(toMultiplyInput) = %+v", cvt)
+	}
+	if len(record.Results) != 3 {
+		t.Errorf("len(Results) = %d, want 3", len(record.Results))
+	}
+
+	// 이름이 중복되면 Build 에러
+	_, err = NewTaskBuilder(Composite).AddNamedFunction("add", add).AddLastNamedFunction("add", add).Build()
+	if err == nil || !strings.Contains(err.Error(), "same name 'add'") {
+		t.Errorf("Build() error = %v, want duplicated name error", err)
+	}
+}
+
+// builder 없이 CompositeTask 에 직접 step 을 추가하는 경우
+func TestCompositeTaskAddSteps(t *testing.T) {
+	task := NewCompositeTask()
+	task.AddFunction(func(ctx context.Context, a any) (any, error) { return a.(int) + 1, nil })
+	task.AddConverter(func(ctx context.Context, a any) (any, error) { return strconv.Itoa(a.(int)), nil })
+
+	if len(task.Steps) != 2 || task.Steps[0].Converter || !task.Steps[1].Converter {
+		t.Errorf("Steps = %+v, want function and converter", task.Steps)
+	}
+	if res, err := task.Execute(context.Background(), 1); err != nil || res != "2" {
+		t.Errorf("Execute() = (%v, %v), want 2", res, err)
+	}
+}
+
+func TestCompositeTaskRecordOnError(t *testing.T) {
+	task := mustBuild(t, NewTaskBuilder(Composite).
+		AddNamedFunction("ok", func(ctx context.Context, a any) (any, error) { return a, nil }).
+		AddLastNamedFunction("fail", func(ctx context.Context, a any) (any, error) { return nil, errors.New("fail") }))
+
+	record, err := task.(*CompositeTask).ExecuteWithRecord(context.Background(), 1)
+	var stepErr *StepError
+	if !errors.As(err, &stepErr) || stepErr.Name != "fail" {
+		t.Fatalf("ExecuteWithRecord() error = %v, want step 'fail' error", err)
+	}
+	if len(record.Results) != 2 || record.Results[1].Err == nil {
+		t.Errorf("record = %+v", record.Results)
+	}
+}
+
+func TestTaskBuilderConverters(t *testing.T) {
+	converters := v2.NewConverterRegistry()
+	if err := v2.RegisterConverter(converters, func(ctx context.Context, i int) (string, error) { return strconv.Itoa(i), nil }); err != nil {
+		t.Fatal(err)
+	}
+	sum := Typed("sum", func(ctx context.Context, in sumInput) (int, error) { return in.A + in.B, nil })
+	exclaim := Typed("exclaim", func(ctx context.Context, in string) (string, error) { return in + "!", nil })
+
+	task := mustBuild(t, NewTaskBuilder(Composite).Converters(converters).AddTypedFunction(sum).AddTypedFunction(exclaim))
+	res, err := task.Execute(context.Background(), sumInput{A: 1, B: 2})
+	if err != nil || res != "3!" {
+		t.Errorf("Execute() = (%v, %v), want 3!", res, err)
+	}
+
+	if _, err := NewTaskBuilder(Composite).AddTypedFunction(sum).AddTypedFunction(exclaim).Build(); err == nil || !strings.Contains(err.Error(), "type mismatch") {
+		t.Errorf("Build() without converters error = %v, want type mismatch", err)
+	}
+	if _, err := NewTaskBuilder(Composite).Converters(v2.NewConverterRegistry()).AddTypedFunction(sum).AddTypedFunction(exclaim).Build(); err == nil || !strings.Contains(err.Error(), "no converter chain") {
+		t.Errorf("Build() error = %v, want no converter chain", err)
+	}
+}
@@ -0,0 +1,40 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	v2 "func_decorator/v2"
+)
+
+// plugin, remote 테스트가 함께 사용하는 function 의 요청, 응답 타입
+type transportInput struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+type transportOutput struct {
+	Sum int `json:"sum"`
+}
+
+// newTransportTestRegistry | plugin, remote 로 제공할 registry
+// add@1.0.0 (math, transportInput -> transportOutput), "boom" 에러를 반환하는 fail, "kaboom" 패닉이 나는 explode 가 등록되어 있습니다.
+func newTransportTestRegistry() FunctionRegistry {
+	registry := NewSimpleFunctionRegistry()
+	registry.RegisterFunctionWithMetadata(FunctionMetadata{
+		Name:       "add",
+		Version:    "1.0.0",
+		Tags:       []string{"math"},
+		InputType:  v2.GetGenericType[transportInput](),
+		OutputType: v2.GetGenericType[transportOutput](),
+	}, func(ctx context.Context, req any) (any, error) {
+		in := req.(transportInput)
+		return transportOutput{Sum: in.A + in.B}, nil
+	})
+	registry.RegisterFunction("fail", func(ctx context.Context, req any) (any, error) {
+		return nil, errors.New("boom")
+	})
+	registry.RegisterFunction("explode", func(ctx context.Context, req any) (any, error) {
+		panic("kaboom")
+	})
+	return registry
+}
@@ -0,0 +1,171 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	v2 "func_decorator/v2"
+)
+
+// TupleField | Tuple 을 구성하는 이름 있는 값
+type TupleField struct {
+	Name  string
+	Value any
+}
+
+// Field | TupleField 를 만듭니다.
+func Field(name string, value any) TupleField {
+	return TupleField{Name: name, Value: value}
+}
+
+// Tuple | 여러 값을 반환하는 function 의 output
+// []any 로 위치를 맞춰 주고받는 대신, 이름으로 값을 꺼낼 수 있음
+type Tuple struct {
+	fields []TupleField
+}
+
+// NewTuple | fields 순서대로 Tuple 을 만듭니다. 같은 이름이 있으면 뒤의 값이 사용됩니다.
+func NewTuple(fields ...TupleField) Tuple {
+	t := Tuple{fields: make([]TupleField, 0, len(fields))}
+	for _, f := range fields {
+		t = t.With(f.Name, f.Value)
+	}
+	return t
+}
+
+// With | name 의 값을 value 로 바꾸거나 추가한 새 Tuple 을 반환합니다.
+func (t Tuple) With(name string, value any) Tuple {
+	fields := make([]TupleField, len(t.fields), len(t.fields)+1)
+	copy(fields, t.fields)
+	for i := range fields {
+		if fields[i].Name == name {
+			fields[i].Value = value
+			return Tuple{fields: fields}
+		}
+	}
+	return Tuple{fields: append(fields, Field(name, value))}
+}
+
+// Get | name 의 값을 반환합니다.
+func (t Tuple) Get(name string) (any, bool) {
+	for _, f := range t.fields {
+		if f.Name == name {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Len | field 의 수를 반환합니다.
+func (t Tuple) Len() int {
+	return len(t.fields)
+}
+
+// Names | field 의 이름을 순서대로 반환합니다.
+func (t Tuple) Names() []string {
+	names := make([]string, len(t.fields))
+	for i, f := range t.fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+func (t Tuple) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("(")
+	for i, f := range t.fields {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(fmt.Sprintf("%s: %v", f.Name, f.Value))
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
+
+// MarshalJSON | field 순서를 유지한 JSON object 로 변환합니다. (trace 기록 용)
+func (t Tuple) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, f := range t.fields {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		name, err := json.Marshal(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, fmt.Errorf("tuple field '%s': %w", f.Name, err)
+		}
+		buf.Write(name)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+// TupleGet | v 가 Tuple 이라면 name 의 값을 T 로 꺼냅니다.
+func TupleGet[T any](v any, name string) (T, error) {
+	var zero T
+	t, ok := v.(Tuple)
+	if !ok {
+		return zero, fmt.Errorf("expected Tuple but got %T", v)
+	}
+	value, ok := t.Get(name)
+	if !ok {
+		return zero, fmt.Errorf("tuple has no field '%s' (fields: %v)", name, t.Names())
+	}
+	typed, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("tuple field '%s' type mismatch, expected %s but got %T", name, v2.GetGenericType[T](), value)
+	}
+	return typed, nil
+}
+
+// Destructure2 | Tuple 에서 두 field 를 타입에 맞게 꺼냅니다.
+func Destructure2[A any, B any](v any, nameA, nameB string) (A, B, error) {
+	var b B
+	a, err := TupleGet[A](v, nameA)
+	if err != nil {
+		return a, b, err
+	}
+	b, err = TupleGet[B](v, nameB)
+	return a, b, err
+}
+
+// Destructure3 | Tuple 에서 세 field 를 타입에 맞게 꺼냅니다.
+func Destructure3[A any, B any, C any](v any, nameA, nameB, nameC string) (A, B, C, error) {
+	var c C
+	a, b, err := Destructure2[A, B](v, nameA, nameB)
+	if err != nil {
+		return a, b, c, err
+	}
+	c, err = TupleGet[C](v, nameC)
+	return a, b, c, err
+}
+
+// TupleConverter | Tuple 의 name field 를 꺼내 fn 에 넘기는 converter 를 만듭니다.
+func TupleConverter[A any, Out any](name string, fn func(ctx context.Context, a A) (Out, error)) FunctionType {
+	return func(ctx context.Context, input any) (any, error) {
+		a, err := TupleGet[A](input, name)
+		if err != nil {
+			return nil, err
+		}
+		return fn(ctx, a)
+	}
+}
+
+// TupleConverter2 | Tuple 의 두 field 를 꺼내 fn 에 넘기는 converter 를 만듭니다.
+func TupleConverter2[A any, B any, Out any](nameA, nameB string, fn func(ctx context.Context, a A, b B) (Out, error)) FunctionType {
+	return func(ctx context.Context, input any) (any, error) {
+		a, b, err := Destructure2[A, B](input, nameA, nameB)
+		if err != nil {
+			return nil, err
+		}
+		return fn(ctx, a, b)
+	}
+}
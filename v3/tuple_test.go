@@ -0,0 +1,53 @@
+package v3
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTuple(t *testing.T) {
+	tuple := NewTuple(Field("sum", 3), Field("input", [2]int{1, 2}))
+
+	if tuple.Len() != 2 || strings.Join(tuple.Names(), ",") != "sum,input" {
+		t.Errorf("Names() = %v", tuple.Names())
+	}
+	sum, input, err := Destructure2[int, [2]int](tuple, "sum", "input")
+	if err != nil || sum != 3 || input != [2]int{1, 2} {
+		t.Errorf("Destructure2() = (%v, %v, %v)", sum, input, err)
+	}
+	if _, err := TupleGet[string](tuple, "sum"); err == nil || !strings.Contains(err.Error(), "expected string but got int") {
+		t.Errorf("TupleGet() error = %v", err)
+	}
+	if _, err := TupleGet[int](tuple, "none"); err == nil || !strings.Contains(err.Error(), "no field 'none'") {
+		t.Errorf("TupleGet() error = %v", err)
+	}
+	if _, err := TupleGet[int]([]any{3}, "sum"); err == nil {
+		t.Errorf("TupleGet() should fail when input is not Tuple")
+	}
+
+	// With 는 원본을 바꾸지 않음
+	changed := tuple.With("sum", 4)
+	if v, _ := tuple.Get("sum"); v != 3 {
+		t.Errorf("original tuple changed: %v", tuple)
+	}
+	if v, _ := changed.Get("sum"); v != 4 {
+		t.Errorf("With() = %v", changed)
+	}
+
+	b, err := json.Marshal(tuple)
+	if err != nil || string(b) != `{"sum":3,"input":[1,2]}` {
+		t.Errorf("MarshalJSON() = (%s, %v)", b, err)
+	}
+}
+
+func TestTupleConverter(t *testing.T) {
+	cvt := TupleConverter2("sum", "input", func(ctx context.Context, sum int, input [2]int) (int, error) {
+		return sum * input[0], nil
+	})
+	res, err := cvt(context.Background(), NewTuple(Field("sum", 3), Field("input", [2]int{2, 1})))
+	if err != nil || res != 6 {
+		t.Errorf("converter = (%v, %v), want 6", res, err)
+	}
+}
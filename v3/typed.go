@@ -0,0 +1,154 @@
+package v3
+
+import (
+	"context"
+	"fmt"
+	v2 "func_decorator/v2"
+	"reflect"
+)
+
+// TypedFunction | 요청, 응답 타입이 명시된 function
+type TypedFunction[In any, Out any] func(ctx context.Context, in In) (Out, error)
+
+// Func | 입력 타입을 검사하는 FunctionType 으로 감쌉니다.
+// 런타임에 입력 타입이 맞지 않으면 name 을 포함한 에러를 반환합니다.
+func (f TypedFunction[In, Out]) Func(name string) FunctionType {
+	return func(ctx context.Context, input any) (any, error) {
+		in, err := castTyped[In](name, "input", input)
+		if err != nil {
+			return nil, err
+		}
+		return f(ctx, in)
+	}
+}
+
+// castTyped | v 를 T 로 변환합니다. nil 은 T 가 nil 을 가질 수 있는 타입일 때만 zero value 로 변환됩니다.
+func castTyped[T any](name, what string, v any) (T, error) {
+	if typed, ok := v.(T); ok {
+		return typed, nil
+	}
+	var zero T
+	t := v2.GetGenericType[T]()
+	if v == nil {
+		switch t.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Slice, reflect.Map, reflect.Func, reflect.Chan:
+			return zero, nil
+		}
+	}
+	return zero, fmt.Errorf("step '%s': %s type mismatch, expected %s but got %T", name, what, t, v)
+}
+
+// TypedStep | 타입 정보를 가진 function 또는 converter step
+type TypedStep interface {
+	Name() string
+	IsConverter() bool
+	InType() reflect.Type
+	OutType() reflect.Type
+	Func() FunctionType
+}
+
+type typedStep struct {
+	name      string
+	converter bool
+	inType    reflect.Type
+	outType   reflect.Type
+	fn        FunctionType
+}
+
+func (s *typedStep) Name() string          { return s.name }
+func (s *typedStep) IsConverter() bool     { return s.converter }
+func (s *typedStep) InType() reflect.Type  { return s.inType }
+func (s *typedStep) OutType() reflect.Type { return s.outType }
+func (s *typedStep) Func() FunctionType    { return s.fn }
+
+// Typed | 타입이 명시된 func 을 function step 으로 만듭니다.
+func Typed[In any, Out any](name string, fn func(ctx context.Context, in In) (Out, error)) TypedStep {
+	return &typedStep{
+		name:    name,
+		inType:  v2.GetGenericType[In](),
+		outType: v2.GetGenericType[Out](),
+		fn:      TypedFunction[In, Out](fn).Func(name),
+	}
+}
+
+// TypedConverter | 타입이 명시된 func 을 converter step 으로 만듭니다.
+func TypedConverter[In any, Out any](name string, fn func(ctx context.Context, in In) (Out, error)) TypedStep {
+	step := Typed[In, Out](name, fn).(*typedStep)
+	step.converter = true
+	return step
+}
+
+// TypedTask | 요청, 응답 타입이 명시된 Task
+type TypedTask[In any, Out any] struct {
+	task Task
+}
+
+// BuildTypedTask | steps 를 순서대로 실행하는 TypedTask 를 만듭니다.
+// In -> steps[0] -> ... -> steps[n-1] -> Out 으로 이어지는 타입이 맞지 않으면 어긋난 step 의 이름과 함께 에러를 반환합니다.
+func BuildTypedTask[In any, Out any](steps ...TypedStep) (*TypedTask[In, Out], error) {
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("typed task has no step")
+	}
+
+	prevName, prevType := "input", v2.GetGenericType[In]()
+	for _, step := range steps {
+		if !prevType.AssignableTo(step.InType()) {
+			return nil, &typedMismatchError{
+				msg: fmt.Sprintf("type mismatch: '%s' returns %s but step '%s' requires %s", prevName, prevType, step.Name(), step.InType()),
+				err: &v2.TypeMismatchError{From: prevName, FromType: prevType, To: step.Name(), ToType: step.InType()},
+			}
+		}
+		prevName, prevType = step.Name(), step.OutType()
+	}
+	if outType := v2.GetGenericType[Out](); !prevType.AssignableTo(outType) {
+		return nil, &typedMismatchError{
+			msg: fmt.Sprintf("type mismatch: last step '%s' returns %s but task output is %s", prevName, prevType, outType),
+			err: &v2.TypeMismatchError{From: prevName, FromType: prevType, To: "output", ToType: outType},
+		}
+	}
+
+	b := NewTaskBuilder(Composite).(*taskBuilder)
+	for i, step := range steps {
+		if step.IsConverter() {
+			b.AttachNamedConverter(step.Name(), step.Func())
+		} else if i == len(steps)-1 {
+			b.AddLastNamedFunction(step.Name(), step.Func())
+		} else {
+			b.AddNamedFunction(step.Name(), step.Func())
+		}
+	}
+	task, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return &TypedTask[In, Out]{task: task}, nil
+}
+
+// typedMismatchError | step 이름을 살린 메시지와 함께 v2.TypeMismatchError 를 감싸는 에러
+type typedMismatchError struct {
+	msg string
+	err *v2.TypeMismatchError
+}
+
+func (e *typedMismatchError) Error() string {
+	return e.msg
+}
+
+func (e *typedMismatchError) Unwrap() error {
+	return e.err
+}
+
+// Execute | Task 를 실행하고 결과를 Out 으로 변환합니다.
+func (t *TypedTask[In, Out]) Execute(ctx context.Context, in In) (Out, error) {
+	res, err := t.task.Execute(ctx, in)
+	if err != nil {
+		var zero Out
+		return zero, err
+	}
+	return castTyped[Out]("output", "output", res)
+}
+
+// Task | Stage, Pipeline 에서 사용할 수 있도록 Task 로 반환합니다.
+func (t *TypedTask[In, Out]) Task() Task {
+	return t.task
+}
@@ -0,0 +1,99 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	v2 "func_decorator/v2"
+)
+
+type sumInput struct{ A, B int }
+
+func TestBuildTypedTask(t *testing.T) {
+	sum := Typed("sum", func(ctx context.Context, in sumInput) (int, error) { return in.A + in.B, nil })
+	toString := TypedConverter("toString", func(ctx context.Context, in int) (string, error) { return strconv.Itoa(in), nil })
+	exclaim := Typed("exclaim", func(ctx context.Context, in string) (string, error) { return in + "!", nil })
+
+	task, err := BuildTypedTask[sumInput, string](sum, toString, exclaim)
+	if err != nil {
+		t.Fatalf("BuildTypedTask() error = %v", err)
+	}
+	res, err := task.Execute(context.Background(), sumInput{A: 1, B: 2})
+	if err != nil || res != "3!" {
+		t.Errorf("Execute() = (%v, %v), want 3!", res, err)
+	}
+
+	// Stage 에서 Task 로 사용
+	stageRes, err := NewConcurrentStage(task.Task()).Run(context.Background(), sumInput{A: 2, B: 2})
+	if err != nil || stageRes[0] != "4!" {
+		t.Errorf("Run() = (%v, %v), want [4!]", stageRes, err)
+	}
+}
+
+func TestBuildTypedTaskMismatch(t *testing.T) {
+	sum := Typed("sum", func(ctx context.Context, in sumInput) (int, error) { return in.A + in.B, nil })
+	exclaim := Typed("exclaim", func(ctx context.Context, in string) (string, error) { return in + "!", nil })
+
+	if _, err := BuildTypedTask[sumInput, string](sum, exclaim); err == nil || !strings.Contains(err.Error(), "'sum' returns int but step 'exclaim' requires string") {
+		t.Errorf("BuildTypedTask() error = %v", err)
+	}
+	if _, err := BuildTypedTask[int, int](sum); err == nil || !strings.Contains(err.Error(), "step 'sum' requires") {
+		t.Errorf("BuildTypedTask() error = %v", err)
+	}
+	if _, err := BuildTypedTask[sumInput, string](sum); err == nil || !strings.Contains(err.Error(), "last step 'sum'") {
+		t.Errorf("BuildTypedTask() error = %v", err)
+	}
+}
+
+func TestTypedFunctionRuntimeMismatch(t *testing.T) {
+	fn := TypedFunction[int, int](func(ctx context.Context, in int) (int, error) { return in, nil }).Func("identity")
+	if _, err := fn(context.Background(), "1"); err == nil || !strings.Contains(err.Error(), "step 'identity': input type mismatch, expected int but got string") {
+		t.Errorf("fn() error = %v", err)
+	}
+
+	ptr := TypedFunction[*sumInput, bool](func(ctx context.Context, in *sumInput) (bool, error) { return in == nil, nil }).Func("isNil")
+	if res, err := ptr(context.Background(), nil); err != nil || res != true {
+		t.Errorf("fn(nil) = (%v, %v), want true", res, err)
+	}
+}
+
+func TestTypedTaskReplay(t *testing.T) {
+	newTask := func(fail bool) *TypedTask[int, int] {
+		pair := Typed("pair", func(ctx context.Context, in int) (sumInput, error) {
+			if fail {
+				return sumInput{}, errors.New("external system down")
+			}
+			return sumInput{A: in, B: in}, nil
+		})
+		sum := Typed("sum", func(ctx context.Context, in sumInput) (int, error) { return in.A + in.B, nil })
+		task, err := BuildTypedTask[int, int](pair, sum)
+		if err != nil {
+			t.Fatalf("BuildTypedTask() error = %v", err)
+		}
+		return task
+	}
+
+	recorder := v2.NewTraceRecorder()
+	bus := v2.NewEventBus()
+	bus.Subscribe(recorder)
+	if _, err := newTask(false).Execute(v2.WithEventBus(context.Background(), bus), 2); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// 기록된 struct output 이 다음 typed step 의 입력 타입으로 decode 됨
+	replay := v2.NewReplay(recorder.Trace()).Substitute("pair", reflect.TypeOf(sumInput{}))
+	res, err := newTask(true).Execute(v2.WithReplay(context.Background(), replay), 0)
+	if err != nil || res != 4 {
+		t.Errorf("Execute() with replay = (%v, %v), want 4", res, err)
+	}
+
+	// v3 step 은 실행기가 타입을 모르므로 타입 없이 대체할 수 없음
+	replay = v2.NewReplay(recorder.Trace()).Substitute("pair", nil)
+	if _, err := newTask(true).Execute(v2.WithReplay(context.Background(), replay), 0); err == nil || !strings.Contains(err.Error(), "no type to decode recorded output of node 'pair'") {
+		t.Errorf("Execute() with untyped replay error = %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package v3
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// WorkerPool | 동시에 실행할 수 있는 Task 의 수를 제한하는 pool
+// 여러 Stage 가 하나의 WorkerPool 을 공유할 수 있으며, 자리가 나면 priority 가 높은 Task 부터 실행됨
+type WorkerPool struct {
+	mu      sync.Mutex
+	size    int
+	running int
+	seq     int
+	waiters waiterQueue
+}
+
+// NewWorkerPool | 최대 size 개의 Task 를 동시에 실행하는 WorkerPool 을 만듭니다.
+// size 가 0 이하라면 1 로 간주하여, Task 를 하나씩 순서대로 실행합니다.
+func NewWorkerPool(size int) *WorkerPool {
+	if size <= 0 {
+		size = 1
+	}
+	return &WorkerPool{size: size}
+}
+
+// Size | 동시에 실행할 수 있는 최대 Task 수를 반환합니다.
+func (p *WorkerPool) Size() int {
+	return p.size
+}
+
+// Acquire | 실행할 자리가 날 때까지 기다립니다.
+// ctx 가 먼저 취소되면 ctx.Err() 를 반환하며, 이 경우 Release 를 호출하면 안 됩니다.
+func (p *WorkerPool) Acquire(ctx context.Context, priority int) error {
+	p.mu.Lock()
+	if p.running < p.size && p.waiters.Len() == 0 {
+		p.running++
+		p.mu.Unlock()
+		return nil
+	}
+	p.seq++
+	w := &waiter{priority: priority, seq: p.seq, ready: make(chan struct{})}
+	heap.Push(&p.waiters, w)
+	p.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		select {
+		case <-w.ready:
+			// 취소와 동시에 자리를 넘겨받았다면 다음 대기자에게 넘겨줌
+			p.release()
+		default:
+			heap.Remove(&p.waiters, w.index)
+		}
+		return ctx.Err()
+	}
+}
+
+// Release | Acquire 로 얻은 자리를 반환합니다.
+func (p *WorkerPool) Release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.release()
+}
+
+func (p *WorkerPool) release() {
+	if p.waiters.Len() > 0 {
+		// running 수는 그대로 두고 자리를 다음 대기자에게 넘김
+		w := heap.Pop(&p.waiters).(*waiter)
+		close(w.ready)
+		return
+	}
+	p.running--
+}
+
+type waiter struct {
+	priority int
+	seq      int
+	index    int
+	ready    chan struct{}
+}
+
+// waiterQueue | priority 가 높은 순, 같으면 먼저 기다린 순으로 정렬되는 heap
+type waiterQueue []*waiter
+
+func (q waiterQueue) Len() int { return len(q) }
+
+func (q waiterQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q waiterQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waiterQueue) Push(x any) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waiterQueue) Pop() any {
+	old := *q
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return w
+}
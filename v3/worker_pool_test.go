@@ -0,0 +1,176 @@
+package v3
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 동시에 실행 중인 Task 의 최대 수를 기록하는 Task
+func countingTask(running, maxRunning *int32) Task {
+	return newFuncTask(func(ctx context.Context, a any) (any, error) {
+		n := atomic.AddInt32(running, 1)
+		for {
+			m := atomic.LoadInt32(maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(running, -1)
+		return a, nil
+	})
+}
+
+func TestConcurrentStageMaxConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	builder := NewStageBuilder().MaxConcurrency(3)
+	for i := 0; i < 50; i++ {
+		builder.AddTask(countingTask(&running, &maxRunning))
+	}
+
+	res, err := builder.Build().Run(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(res) != 50 {
+		t.Errorf("len(results) = %d, want 50", len(res))
+	}
+	if maxRunning > 3 {
+		t.Errorf("max concurrent tasks = %d, want <= 3", maxRunning)
+	}
+}
+
+func TestNewWorkerPoolSize(t *testing.T) {
+	for size, want := range map[int]int{-1: 1, 0: 1, 1: 1, 4: 4} {
+		if got := NewWorkerPool(size).Size(); got != want {
+			t.Errorf("NewWorkerPool(%d).Size() = %d, want %d", size, got, want)
+		}
+	}
+}
+
+func TestWorkerPoolSharedAcrossStages(t *testing.T) {
+	var running, maxRunning int32
+	pool := NewWorkerPool(4)
+	newStage := func() Stage {
+		builder := NewStageBuilder().Pool(pool)
+		for i := 0; i < 20; i++ {
+			builder.AddTask(countingTask(&running, &maxRunning))
+		}
+		return builder.Build()
+	}
+
+	var wg sync.WaitGroup
+	for _, stage := range []Stage{newStage(), newStage(), newStage()} {
+		wg.Add(1)
+		go func(s Stage) {
+			defer wg.Done()
+			if _, err := s.Run(context.Background(), 1); err != nil {
+				t.Errorf("Run() error = %v", err)
+			}
+		}(stage)
+	}
+	wg.Wait()
+
+	if maxRunning > 4 {
+		t.Errorf("max concurrent tasks = %d, want <= 4", maxRunning)
+	}
+}
+
+func TestWorkerPoolPriority(t *testing.T) {
+	pool := NewWorkerPool(1)
+	if err := pool.Acquire(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	order := make([]int, 0)
+	var wg sync.WaitGroup
+	for i, priority := range []int{1, 5, 3} {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			if err := pool.Acquire(context.Background(), p); err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			order = append(order, p)
+			mu.Unlock()
+			pool.Release()
+		}(priority)
+		// 대기열에 순서대로 들어가도록 기다림
+		waitForWaiters(pool, i+1)
+	}
+	pool.Release()
+	wg.Wait()
+
+	if order[0] != 5 || order[1] != 3 || order[2] != 1 {
+		t.Errorf("execution order = %v, want [5 3 1]", order)
+	}
+}
+
+func TestConcurrentStagePriority(t *testing.T) {
+	var mu sync.Mutex
+	order := make([]int, 0)
+	recordTask := func(id int) Task {
+		return newFuncTask(func(ctx context.Context, a any) (any, error) {
+			mu.Lock()
+			order = append(order, id)
+			mu.Unlock()
+			return id, nil
+		})
+	}
+
+	// 가장 높은 priority 가 맨 앞에 있어도 먼저 실행되어야 함
+	priorities := []int{9, 1, 5, 1, 7}
+	builder := NewStageBuilder().MaxConcurrency(1)
+	for i, priority := range priorities {
+		builder.AddTaskWithPriority(recordTask(i), priority)
+	}
+	res, err := builder.Build().Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(res) != 5 || res[0] != 0 || res[4] != 4 {
+		t.Errorf("Run() results = %v, want results in task order", res)
+	}
+	want := []int{0, 4, 2, 1, 3}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("execution order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestWorkerPoolAcquireCancel(t *testing.T) {
+	pool := NewWorkerPool(1)
+	if err := pool.Acquire(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := pool.Acquire(ctx, 0); err == nil {
+		t.Errorf("Acquire() should fail when ctx is done")
+	}
+
+	// 취소된 대기자는 대기열에서 빠져야 함
+	pool.Release()
+	if err := pool.Acquire(context.Background(), 0); err != nil {
+		t.Errorf("Acquire() error = %v", err)
+	}
+}
+
+func waitForWaiters(pool *WorkerPool, n int) {
+	for {
+		pool.mu.Lock()
+		l := pool.waiters.Len()
+		pool.mu.Unlock()
+		if l >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}